@@ -0,0 +1,245 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var batchers = &readBatcher{batches: make(map[string]*readBatch)}
+
+// readBatcher coalesces the reads issued to the same device within a short window
+// into a single HandleReadCommands call.
+type readBatcher struct {
+	batches map[string]*readBatch
+	mutex   sync.Mutex
+}
+
+// readBatch is the set of CommandRequests gathered for one device. The merged requests
+// are deduplicated, and the results are shared by all waiters once done is closed.
+type readBatch struct {
+	device        models.Device
+	correlationID interface{}
+	reqs          []sdkModels.CommandRequest
+	deadline      time.Time
+	noDeadline    bool
+	done          chan struct{}
+	values        []*sdkModels.CommandValue
+	err           error
+	// streamsTaken holds the indexes of the streamed values handed to a waiter, since a
+	// stream can only be read once
+	streamsTaken map[int]bool
+}
+
+// batchSettings resolves the batching window and the maximum number of merged requests of
+// the device. The Device.ReadBatching configuration is overridden by the ds-batchwindow and
+// ds-batchmaxrequests protocol properties of the device.
+func batchSettings(device models.Device, info config.ReadBatchingInfo) (window time.Duration, maxRequests int, err errors.EdgeX) {
+	windowStr := info.Window
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.BatchWindow); ok {
+		windowStr = v
+	}
+	if windowStr != "" {
+		var parseErr error
+		window, parseErr = time.ParseDuration(windowStr)
+		if parseErr != nil {
+			errMsg := fmt.Sprintf("failed to parse read batching window %s of device %s", windowStr, device.Name)
+			return 0, 0, errors.NewCommonEdgeX(errors.KindServerError, errMsg, parseErr)
+		}
+	}
+
+	maxRequests = info.MaxRequests
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.BatchMaxRequests); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			maxRequests = n
+		}
+	}
+
+	return window, maxRequests, nil
+}
+
+// read adds reqs to the pending batch of the device, or opens a new batch flushed after
+// window, and waits for the batch results. The batch is flushed early once it holds
+// maxRequests requests.
+func (b *readBatcher) read(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, window time.Duration, maxRequests int, dic *di.Container) ([]*sdkModels.CommandValue, error) {
+	b.mutex.Lock()
+	batch, ok := b.batches[device.Name]
+	if !ok {
+		batch = &readBatch{
+			device:        device,
+			correlationID: ctx.Value(common.CorrelationHeader),
+			done:          make(chan struct{}),
+		}
+		b.batches[device.Name] = batch
+		time.AfterFunc(window, func() { b.flush(batch, dic) })
+	}
+	indexes := batch.add(ctx, reqs)
+	if maxRequests > 0 && len(batch.reqs) >= maxRequests {
+		// detached right away so that no later read joins the batch beyond maxRequests
+		delete(b.batches, device.Name)
+		go batch.execute(dic)
+	}
+	b.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-batch.done:
+	}
+	var partial sdkModels.PartialReadError
+	if stdErrors.As(batch.err, &partial) {
+		return partialBatchResult(reqs, batch.values, partial)
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	if len(batch.values) != len(batch.reqs) {
+		return nil, fmt.Errorf("expected %d CommandValues from the batched read of %s, got %d", len(batch.reqs), device.Name, len(batch.values))
+	}
+
+	// every waiter gets its own copy since the values are transformed in place afterwards
+	values := make([]*sdkModels.CommandValue, len(indexes))
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for i, index := range indexes {
+		if !batch.takeStream(index) {
+			return nil, fmt.Errorf("streamed value of %s already consumed by a concurrent read of %s", reqs[i].DeviceResourceName, device.Name)
+		}
+		values[i] = copyCommandValue(batch.values[index])
+	}
+	return values, nil
+}
+
+// takeStream returns false if the value at index is a stream already handed to a waiter.
+func (batch *readBatch) takeStream(index int) bool {
+	cv := batch.values[index]
+	if cv == nil {
+		return true
+	}
+	if _, ok := cv.BinaryStream(); !ok {
+		return true
+	}
+	if batch.streamsTaken[index] {
+		return false
+	}
+	if batch.streamsTaken == nil {
+		batch.streamsTaken = make(map[int]bool)
+	}
+	batch.streamsTaken[index] = true
+	return true
+}
+
+// flush issues the merged read of the batch unless it is already flushed.
+func (b *readBatcher) flush(batch *readBatch, dic *di.Container) {
+	b.mutex.Lock()
+	if b.batches[batch.device.Name] != batch {
+		b.mutex.Unlock()
+		return
+	}
+	delete(b.batches, batch.device.Name)
+	b.mutex.Unlock()
+
+	batch.execute(dic)
+}
+
+// execute issues the merged read of a batch detached from the readBatcher.
+func (batch *readBatch) execute(dic *di.Container) {
+	ctx, cancel := batch.context()
+	defer cancel()
+	batch.values, batch.err = executeRead(ctx, batch.device, batch.reqs, dic)
+	close(batch.done)
+}
+
+// add merges reqs into the batch and returns the index of each request in the merged slice.
+// It must be called with the readBatcher mutex held.
+func (batch *readBatch) add(ctx context.Context, reqs []sdkModels.CommandRequest) []int {
+	if deadline, ok := ctx.Deadline(); !ok {
+		batch.noDeadline = true
+	} else if deadline.After(batch.deadline) {
+		batch.deadline = deadline
+	}
+
+	indexes := make([]int, len(reqs))
+	for i, req := range reqs {
+		indexes[i] = -1
+		for j, merged := range batch.reqs {
+			if merged.DeviceResourceName == req.DeviceResourceName && merged.Type == req.Type &&
+				reflect.DeepEqual(merged.Attributes, req.Attributes) {
+				indexes[i] = j
+				break
+			}
+		}
+		if indexes[i] < 0 {
+			indexes[i] = len(batch.reqs)
+			batch.reqs = append(batch.reqs, req)
+		}
+	}
+	return indexes
+}
+
+// context returns the context of the merged read, which is bound to the latest deadline
+// of the waiters so that no waiter is cut short by another one.
+func (batch *readBatch) context() (context.Context, context.CancelFunc) {
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, batch.correlationID) // nolint: staticcheck
+	if batch.noDeadline || batch.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, batch.deadline)
+}
+
+// partialBatchResult picks the values of reqs out of a partially failed batch, along with
+// the failures of reqs only.
+func partialBatchResult(reqs []sdkModels.CommandRequest, batchValues []*sdkModels.CommandValue, partial sdkModels.PartialReadError) ([]*sdkModels.CommandValue, error) {
+	valueMap := make(map[string]*sdkModels.CommandValue, len(batchValues))
+	for _, cv := range batchValues {
+		if cv != nil {
+			valueMap[cv.DeviceResourceName] = cv
+		}
+	}
+
+	values := make([]*sdkModels.CommandValue, 0, len(reqs))
+	errs := make(map[string]error)
+	for _, req := range reqs {
+		if cv, ok := valueMap[req.DeviceResourceName]; ok {
+			values = append(values, copyCommandValue(cv))
+		} else if err, ok := partial.Errors[req.DeviceResourceName]; ok {
+			errs[req.DeviceResourceName] = err
+		} else {
+			errs[req.DeviceResourceName] = fmt.Errorf("no value read for %s", req.DeviceResourceName)
+		}
+	}
+	return values, sdkModels.NewPartialReadError(errs)
+}
+
+func copyCommandValue(cv *sdkModels.CommandValue) *sdkModels.CommandValue {
+	if cv == nil {
+		return nil
+	}
+	result := *cv
+	if cv.Tags != nil {
+		result.Tags = make(map[string]string, len(cv.Tags))
+		for k, v := range cv.Tags {
+			result.Tags[k] = v
+		}
+	}
+	return &result
+}
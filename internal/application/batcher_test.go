@@ -0,0 +1,176 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestReadBatcher_Coalesce(t *testing.T) {
+	r1 := sdkModels.CommandRequest{DeviceResourceName: "r1", Type: common.ValueTypeInt16}
+	r2 := sdkModels.CommandRequest{DeviceResourceName: "r2", Type: common.ValueTypeInt16}
+	r3 := sdkModels.CommandRequest{DeviceResourceName: "r3", Type: common.ValueTypeInt16}
+	v1, _ := sdkModels.NewCommandValue("r1", common.ValueTypeInt16, int16(1))
+	v2, _ := sdkModels.NewCommandValue("r2", common.ValueTypeInt16, int16(2))
+	v3, _ := sdkModels.NewCommandValue("r3", common.ValueTypeInt16, int16(3))
+
+	valueMap := map[string]*sdkModels.CommandValue{"r1": v1, "r2": v2, "r3": v3}
+	driver := &mocks.ProtocolDriver{}
+	// the merged order depends on the arrival of the readers
+	driver.On("HandleReadCommands", "test-device", testProtocols, mock.MatchedBy(func(reqs []sdkModels.CommandRequest) bool {
+		if len(reqs) != 3 {
+			return false
+		}
+		for _, req := range reqs {
+			if _, ok := valueMap[req.DeviceResourceName]; !ok {
+				return false
+			}
+		}
+		return true
+	})).Return(func(_ string, _ map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) []*sdkModels.CommandValue {
+		values := make([]*sdkModels.CommandValue, len(reqs))
+		for i, req := range reqs {
+			values[i] = valueMap[req.DeviceResourceName]
+		}
+		return values
+	}, nil).Once()
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+
+	b := &readBatcher{batches: make(map[string]*readBatch)}
+	tests := []struct {
+		name     string
+		reqs     []sdkModels.CommandRequest
+		expected []*sdkModels.CommandValue
+	}{
+		{"first reader", []sdkModels.CommandRequest{r1, r2}, []*sdkModels.CommandValue{v1, v2}},
+		{"overlapping reader", []sdkModels.CommandRequest{r2, r3}, []*sdkModels.CommandValue{v2, v3}},
+		{"duplicate reader", []sdkModels.CommandRequest{r1}, []*sdkModels.CommandValue{v1}},
+	}
+
+	var wg sync.WaitGroup
+	for _, testCase := range tests {
+		wg.Add(1)
+		go func(reqs []sdkModels.CommandRequest, expected []*sdkModels.CommandValue) {
+			defer wg.Done()
+			values, err := b.read(context.Background(), testDevice, reqs, 50*time.Millisecond, 0, dic)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, values)
+		}(testCase.reqs, testCase.expected)
+	}
+	wg.Wait()
+
+	driver.AssertNumberOfCalls(t, "HandleReadCommands", 1)
+	assert.Empty(t, b.batches)
+}
+
+func TestReadBatcher_MaxRequests(t *testing.T) {
+	r1 := sdkModels.CommandRequest{DeviceResourceName: "r1", Type: common.ValueTypeInt16}
+	v1, _ := sdkModels.NewCommandValue("r1", common.ValueTypeInt16, int16(1))
+
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", "test-device", testProtocols, []sdkModels.CommandRequest{r1}).
+		Return([]*sdkModels.CommandValue{v1}, nil)
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+
+	b := &readBatcher{batches: make(map[string]*readBatch)}
+	start := time.Now()
+	values, err := b.read(context.Background(), testDevice, []sdkModels.CommandRequest{r1}, time.Hour, 1, dic)
+	require.NoError(t, err)
+	assert.Equal(t, []*sdkModels.CommandValue{v1}, values)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestReadBatcher_MaxRequests_LateReaders(t *testing.T) {
+	const maxRequests = 2
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", "test-device", testProtocols, mock.Anything).Return(
+		func(_ string, _ map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) []*sdkModels.CommandValue {
+			time.Sleep(time.Millisecond)
+			values := make([]*sdkModels.CommandValue, len(reqs))
+			for i, req := range reqs {
+				values[i], _ = sdkModels.NewCommandValue(req.DeviceResourceName, common.ValueTypeInt16, int16(i))
+			}
+			return values
+		}, nil)
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+
+	b := &readBatcher{batches: make(map[string]*readBatch)}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := sdkModels.CommandRequest{DeviceResourceName: fmt.Sprintf("r%d", i), Type: common.ValueTypeInt16}
+			_, err := b.read(context.Background(), testDevice, []sdkModels.CommandRequest{req}, 100*time.Millisecond, maxRequests, dic)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, call := range driver.Calls {
+		assert.LessOrEqual(t, len(call.Arguments.Get(2).([]sdkModels.CommandRequest)), maxRequests)
+	}
+}
+
+func TestReadBatcher_CopyValues(t *testing.T) {
+	r1 := sdkModels.CommandRequest{DeviceResourceName: "r1", Type: common.ValueTypeInt16}
+	v1, _ := sdkModels.NewCommandValue("r1", common.ValueTypeInt16, int16(1))
+
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", "test-device", testProtocols, []sdkModels.CommandRequest{r1}).
+		Return([]*sdkModels.CommandValue{v1}, nil).Once()
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+
+	b := &readBatcher{batches: make(map[string]*readBatch)}
+	results := make(chan []*sdkModels.CommandValue, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			values, err := b.read(context.Background(), testDevice, []sdkModels.CommandRequest{r1}, 50*time.Millisecond, 0, dic)
+			assert.NoError(t, err)
+			results <- values
+		}()
+	}
+	first, second := <-results, <-results
+	require.Len(t, first, 1)
+	require.Len(t, second, 1)
+	assert.NotSame(t, first[0], second[0])
+}
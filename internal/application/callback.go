@@ -12,6 +12,8 @@ import (
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -63,6 +65,9 @@ func AddDevice(addDeviceRequest requests.AddDeviceRequest, dic *di.Container) er
 		errMsg := fmt.Sprintf("driver.AddDevice callback failed for %s", device.Name)
 		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
 	}
+	if device.OperatingState == models.Down {
+		health.startProbe(device.Name, dic)
+	}
 
 	lc.Debugf("starting AutoEvents for device %s", device.Name)
 	container.ManagerFrom(dic.Get).RestartForDevice(device.Name)
@@ -91,6 +96,7 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 		return DeleteDevice(*updateDeviceRequest.Device.Name, dic)
 	}
 
+	previous := device
 	requests.ReplaceDeviceModelFieldsWithDTO(&device, updateDeviceRequest.Device)
 	edgexErr := updateAssociatedProfile(device.ProfileName, dic)
 	if edgexErr != nil {
@@ -103,6 +109,15 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
 	}
 	lc.Debugf("device %s updated", device.Name)
+	limiters.update(previous, device, container.ConfigurationFrom(dic.Get).Device.Concurrency)
+	readCache.invalidate(device.Name)
+	transformer.ForgetDeadband(device.Name)
+	// a device set DOWN elsewhere, e.g. by a failed assertion, is probed for recovery as well
+	if device.OperatingState == models.Down {
+		health.startProbe(device.Name, dic)
+	} else {
+		health.stopProbe(device.Name)
+	}
 
 	driver := container.ProtocolDriverFrom(dic.Get)
 	err := driver.UpdateDevice(device.Name, device.Protocols, device.AdminState)
@@ -130,12 +145,21 @@ func DeleteDevice(name string, dic *di.Container) errors.EdgeX {
 	device, ok := cache.Devices().ForName(name)
 	if ok {
 		lc.Debugf("stopping AutoEvents for device %s", device.Name)
-		container.ManagerFrom(dic.Get).StopForDevice(device.Name)
+		manager := container.ManagerFrom(dic.Get)
+		manager.StopForDevice(device.Name)
+		if controller, ok := manager.(sdkModels.AutoEventController); ok {
+			controller.ForgetDevice(device.Name)
+		}
 	} else {
 		errMsg := fmt.Sprintf("failed to find device %s", name)
 		return errors.NewCommonEdgeX(errors.KindInvalidId, errMsg, nil)
 	}
 
+	limiters.remove(device, container.ConfigurationFrom(dic.Get).Device.Concurrency)
+	readCache.invalidate(device.Name)
+	transformer.ForgetDeadband(device.Name)
+	health.stopProbe(device.Name)
+
 	// remove the device in cache
 	edgexErr := cache.Devices().RemoveByName(name)
 	if edgexErr != nil {
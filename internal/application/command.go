@@ -8,6 +8,7 @@ package application
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -31,6 +32,7 @@ import (
 )
 
 type CommandProcessor struct {
+	ctx           context.Context
 	device        models.Device
 	sourceName    string
 	correlationID string
@@ -39,11 +41,12 @@ type CommandProcessor struct {
 	dic           *di.Container
 }
 
-func NewCommandProcessor(device models.Device, sourceName string, correlationID string, setParamsMap map[string]interface{}, attributes string, dic *di.Container) *CommandProcessor {
+func NewCommandProcessor(ctx context.Context, device models.Device, sourceName string, correlationID string, setParamsMap map[string]interface{}, attributes string, dic *di.Container) *CommandProcessor {
 	if setParamsMap == nil {
 		setParamsMap = make(map[string]interface{})
 	}
 	return &CommandProcessor{
+		ctx:           ctx,
 		device:        device,
 		sourceName:    sourceName,
 		correlationID: correlationID,
@@ -53,7 +56,9 @@ func NewCommandProcessor(device models.Device, sourceName string, correlationID
 	}
 }
 
-func CommandHandler(isRead bool, sendEvent bool, correlationID string, vars map[string]string, setParamsMap map[string]interface{}, attributes string, dic *di.Container) (res *dtos.Event, err errors.EdgeX) {
+// CommandHandler executes the GET or SET command specified in vars against the ProtocolDriver.
+// The driver operation is bound to ctx, which is cancelled when the command deadline passes.
+func CommandHandler(ctx context.Context, isRead bool, sendEvent bool, correlationID string, vars map[string]string, setParamsMap map[string]interface{}, attributes string, dic *di.Container) (res *dtos.Event, err errors.EdgeX) {
 	// check device service AdminState
 	ds := container.DeviceServiceFrom(dic.Get)
 	if ds.AdminState == models.Locked {
@@ -93,8 +98,11 @@ func CommandHandler(isRead bool, sendEvent bool, correlationID string, vars map[
 		}
 	}()
 
+	ctx, cancel := commandContext(ctx, correlationID, dic)
+	defer cancel()
+
 	cmd := vars[common.Command]
-	helper := NewCommandProcessor(device, cmd, correlationID, setParamsMap, attributes, dic)
+	helper := NewCommandProcessor(ctx, device, cmd, correlationID, setParamsMap, attributes, dic)
 	_, cmdExist := cache.Profiles().DeviceCommand(device.ProfileName, cmd)
 	if cmdExist {
 		if isRead {
@@ -142,11 +150,11 @@ func (c *CommandProcessor) ReadDeviceResource() (res *dtos.Event, e errors.EdgeX
 	reqs = append(reqs, req)
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	results, err := driver.HandleReadCommands(c.device.Name, c.device.Protocols, reqs)
+	results, err := c.readWithCache(reqs)
+	results, failures, err := partialResults(results, err)
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceResourece %s for %s", dr.Name, c.device.Name)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return res, driverError(c.ctx, errMsg, err)
 	}
 
 	// convert CommandValue to Event
@@ -154,6 +162,7 @@ func (c *CommandProcessor) ReadDeviceResource() (res *dtos.Event, e errors.EdgeX
 	if e != nil {
 		return res, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", e)
 	}
+	tagFailedResources(res, failures)
 
 	return
 }
@@ -202,11 +211,11 @@ func (c *CommandProcessor) ReadDeviceCommand() (res *dtos.Event, e errors.EdgeX)
 	}
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	results, err := driver.HandleReadCommands(c.device.Name, c.device.Protocols, reqs)
+	results, err := c.readWithCache(reqs)
+	results, failures, err := partialResults(results, err)
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceCommand %s for %s", dc.Name, c.device.Name)
-		return res, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return res, driverError(c.ctx, errMsg, err)
 	}
 
 	// convert CommandValue to Event
@@ -214,6 +223,7 @@ func (c *CommandProcessor) ReadDeviceCommand() (res *dtos.Event, e errors.EdgeX)
 	if e != nil {
 		return res, errors.NewCommonEdgeX(errors.KindServerError, "failed to transform CommandValue to Event", e)
 	}
+	tagFailedResources(res, failures)
 
 	return
 }
@@ -263,6 +273,7 @@ func (c *CommandProcessor) WriteDeviceResource() (e errors.EdgeX) {
 	reqs[0].Type = cv.Type
 
 	// transform write value
+	requested := copyCommandValue(cv)
 	configuration := container.ConfigurationFrom(c.dic.Get)
 	if configuration.Device.DataTransform {
 		e = transformer.TransformWriteParameter(cv, dr.Properties)
@@ -272,14 +283,14 @@ func (c *CommandProcessor) WriteDeviceResource() (e errors.EdgeX) {
 	}
 
 	// execute protocol-specific write operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	err := driver.HandleWriteCommands(c.device.Name, c.device.Protocols, reqs, []*sdkModels.CommandValue{cv})
+	err := c.write(reqs, []*sdkModels.CommandValue{cv})
+	readCache.invalidate(c.device.Name, dr.Name)
 	if err != nil {
 		errMsg := fmt.Sprintf("error writing DeviceResourece %s for %s", dr.Name, c.device.Name)
-		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return driverError(c.ctx, errMsg, err)
 	}
 
-	return nil
+	return c.verifyWrite(reqs, []*sdkModels.CommandValue{requested})
 }
 
 func (c *CommandProcessor) WriteDeviceCommand() errors.EdgeX {
@@ -347,6 +358,12 @@ func (c *CommandProcessor) WriteDeviceCommand() errors.EdgeX {
 		}
 	}
 
+	// keep the requested values for verification before the write transformations
+	requested := make([]*sdkModels.CommandValue, len(cvs))
+	for i, cv := range cvs {
+		requested[i] = copyCommandValue(cv)
+	}
+
 	// prepare CommandRequests
 	reqs := make([]sdkModels.CommandRequest, len(cvs))
 	for i, cv := range cvs {
@@ -372,14 +389,16 @@ func (c *CommandProcessor) WriteDeviceCommand() errors.EdgeX {
 	}
 
 	// execute protocol-specific write operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	err := driver.HandleWriteCommands(c.device.Name, c.device.Protocols, reqs, cvs)
+	err := c.write(reqs, cvs)
+	for _, req := range reqs {
+		readCache.invalidate(c.device.Name, req.DeviceResourceName)
+	}
 	if err != nil {
 		errMsg := fmt.Sprintf("error writing DeviceCommand %s for %s", dc.Name, c.device.Name)
-		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+		return driverError(c.ctx, errMsg, err)
 	}
 
-	return nil
+	return c.verifyWrite(reqs, requested)
 }
 
 func createCommandValueFromDeviceResource(dr models.DeviceResource, value interface{}) (*sdkModels.CommandValue, errors.EdgeX) {
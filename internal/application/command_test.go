@@ -7,7 +7,10 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
@@ -23,8 +26,11 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -178,9 +184,9 @@ func TestCommandProcessor_ReadDeviceResource(t *testing.T) {
 	err := cache.InitCache("test-service", dic)
 	require.NoError(t, err)
 
-	valid := NewCommandProcessor(testDevice, "test-resource", uuid.NewString(), nil, "", dic)
-	invalidDeviceResource := NewCommandProcessor(testDevice, "invalid", uuid.NewString(), nil, "", dic)
-	writeOnlyDeviceResource := NewCommandProcessor(testDevice, "wo-resource", uuid.NewString(), nil, "", dic)
+	valid := NewCommandProcessor(context.Background(), testDevice, "test-resource", uuid.NewString(), nil, "", dic)
+	invalidDeviceResource := NewCommandProcessor(context.Background(), testDevice, "invalid", uuid.NewString(), nil, "", dic)
+	writeOnlyDeviceResource := NewCommandProcessor(context.Background(), testDevice, "wo-resource", uuid.NewString(), nil, "", dic)
 
 	tests := []struct {
 		name             string
@@ -209,10 +215,10 @@ func TestCommandProcessor_ReadDeviceCommand(t *testing.T) {
 	err := cache.InitCache("test-service", dic)
 	require.NoError(t, err)
 
-	valid := NewCommandProcessor(testDevice, "test-command", uuid.NewString(), nil, "", dic)
-	invalidDeviceCommand := NewCommandProcessor(testDevice, "invalid", uuid.NewString(), nil, "", dic)
-	writeOnlyDeviceCommand := NewCommandProcessor(testDevice, "wo-command", uuid.NewString(), nil, "", dic)
-	outOfRangeResourceOperation := NewCommandProcessor(testDevice, "exceed-command", uuid.NewString(), nil, "", dic)
+	valid := NewCommandProcessor(context.Background(), testDevice, "test-command", uuid.NewString(), nil, "", dic)
+	invalidDeviceCommand := NewCommandProcessor(context.Background(), testDevice, "invalid", uuid.NewString(), nil, "", dic)
+	writeOnlyDeviceCommand := NewCommandProcessor(context.Background(), testDevice, "wo-command", uuid.NewString(), nil, "", dic)
+	outOfRangeResourceOperation := NewCommandProcessor(context.Background(), testDevice, "exceed-command", uuid.NewString(), nil, "", dic)
 
 	tests := []struct {
 		name             string
@@ -242,12 +248,12 @@ func TestCommandProcessor_WriteDeviceResource(t *testing.T) {
 	err := cache.InitCache("test-service", dic)
 	require.NoError(t, err)
 
-	valid := NewCommandProcessor(testDevice, "test-resource", uuid.NewString(), map[string]interface{}{"test-resource": "test-value"}, "", dic)
-	validObjectValue := NewCommandProcessor(testDevice, "rw-object", uuid.NewString(), map[string]interface{}{"rw-object": map[string]interface{}{"foo": "bar"}}, "", dic)
-	invalidDeviceResource := NewCommandProcessor(testDevice, "invalid", uuid.NewString(), nil, "", dic)
-	readOnlyDeviceResource := NewCommandProcessor(testDevice, "ro-resource", uuid.NewString(), nil, "", dic)
-	noRequestBody := NewCommandProcessor(testDevice, "test-resource", uuid.NewString(), nil, "", dic)
-	invalidRequestBody := NewCommandProcessor(testDevice, "test-resource", uuid.NewString(), map[string]interface{}{"wrong-resource": "wrong-value"}, "", dic)
+	valid := NewCommandProcessor(context.Background(), testDevice, "test-resource", uuid.NewString(), map[string]interface{}{"test-resource": "test-value"}, "", dic)
+	validObjectValue := NewCommandProcessor(context.Background(), testDevice, "rw-object", uuid.NewString(), map[string]interface{}{"rw-object": map[string]interface{}{"foo": "bar"}}, "", dic)
+	invalidDeviceResource := NewCommandProcessor(context.Background(), testDevice, "invalid", uuid.NewString(), nil, "", dic)
+	readOnlyDeviceResource := NewCommandProcessor(context.Background(), testDevice, "ro-resource", uuid.NewString(), nil, "", dic)
+	noRequestBody := NewCommandProcessor(context.Background(), testDevice, "test-resource", uuid.NewString(), nil, "", dic)
+	invalidRequestBody := NewCommandProcessor(context.Background(), testDevice, "test-resource", uuid.NewString(), map[string]interface{}{"wrong-resource": "wrong-value"}, "", dic)
 
 	tests := []struct {
 		name             string
@@ -279,12 +285,12 @@ func TestCommandProcessor_WriteDeviceCommand(t *testing.T) {
 	err := cache.InitCache("test-service", dic)
 	require.NoError(t, err)
 
-	valid := NewCommandProcessor(testDevice, "test-command", uuid.NewString(), map[string]interface{}{"test-resource": "test-value"}, "", dic)
-	invalidDeviceCommand := NewCommandProcessor(testDevice, "invalid", uuid.NewString(), nil, "", dic)
-	readOnlyDeviceCommand := NewCommandProcessor(testDevice, "ro-command", uuid.NewString(), nil, "", dic)
-	outOfRangeResourceOperation := NewCommandProcessor(testDevice, "exceed-command", uuid.NewString(), nil, "", dic)
-	noRequestBody := NewCommandProcessor(testDevice, "test-command", uuid.NewString(), nil, "", dic)
-	invalidRequestBody := NewCommandProcessor(testDevice, "test-command", uuid.NewString(), map[string]interface{}{"wrong-resource": "wrong-value"}, "", dic)
+	valid := NewCommandProcessor(context.Background(), testDevice, "test-command", uuid.NewString(), map[string]interface{}{"test-resource": "test-value"}, "", dic)
+	invalidDeviceCommand := NewCommandProcessor(context.Background(), testDevice, "invalid", uuid.NewString(), nil, "", dic)
+	readOnlyDeviceCommand := NewCommandProcessor(context.Background(), testDevice, "ro-command", uuid.NewString(), nil, "", dic)
+	outOfRangeResourceOperation := NewCommandProcessor(context.Background(), testDevice, "exceed-command", uuid.NewString(), nil, "", dic)
+	noRequestBody := NewCommandProcessor(context.Background(), testDevice, "test-command", uuid.NewString(), nil, "", dic)
+	invalidRequestBody := NewCommandProcessor(context.Background(), testDevice, "test-command", uuid.NewString(), map[string]interface{}{"wrong-resource": "wrong-value"}, "", dic)
 
 	tests := []struct {
 		name             string
@@ -310,3 +316,110 @@ func TestCommandProcessor_WriteDeviceCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandProcessor_ReadDeviceResource_Deadline(t *testing.T) {
+	cr := sdkModels.CommandRequest{
+		DeviceResourceName: "test-resource",
+		Attributes:         nil,
+		Type:               "String",
+	}
+	hungDriver := &mocks.ProtocolDriver{}
+	hungDriver.On("HandleReadCommands", "test-device", testProtocols, []sdkModels.CommandRequest{cr}).After(time.Second).Return(nil, nil)
+	contextDriver := &mocks.ContextualProtocolDriver{}
+	contextDriver.On("HandleReadCommandsWithContext", mock.Anything, "test-device", testProtocols, []sdkModels.CommandRequest{cr}).Return(
+		func(ctx context.Context, _ string, _ map[string]models.ProtocolProperties, _ []sdkModels.CommandRequest) []*sdkModels.CommandValue {
+			<-ctx.Done()
+			return nil
+		},
+		func(ctx context.Context, _ string, _ map[string]models.ProtocolProperties, _ []sdkModels.CommandRequest) error {
+			return ctx.Err()
+		})
+
+	tests := []struct {
+		name          string
+		contextDriver sdkModels.ContextualProtocolDriver
+	}{
+		{"ProtocolDriver not returning", nil},
+		{"ContextualProtocolDriver honoring the deadline", contextDriver},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ProtocolDriverName: func(get di.Get) interface{} {
+					return hungDriver
+				},
+				container.ContextualProtocolDriverName: func(get di.Get) interface{} {
+					return tt.contextDriver
+				},
+			})
+			err := cache.InitCache("test-service", dic)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err = NewCommandProcessor(ctx, testDevice, "test-resource", uuid.NewString(), nil, "", dic).ReadDeviceResource()
+			require.Error(t, err)
+			assert.Equal(t, http.StatusGatewayTimeout, err.Code())
+		})
+	}
+}
+
+func TestDriverError(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		err          error
+		expectedCode int
+	}{
+		{"deadline exceeded", expired, context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"deadline of the batched read exceeded", context.Background(), context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"deadline of the driver exceeded", context.Background(), fmt.Errorf("read failed: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{"request cancelled", cancelled, context.Canceled, http.StatusServiceUnavailable},
+		{"command queue full", context.Background(), queueFullError{kindedError{errors.NewCommonEdgeX(errors.KindServiceUnavailable, "full", nil)}}, http.StatusServiceUnavailable},
+		{"driver failure", context.Background(), fmt.Errorf("read failed"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := driverError(tt.ctx, "failed to read", tt.err)
+			assert.Equal(t, tt.expectedCode, err.Code())
+		})
+	}
+}
+
+func TestValidateCommandTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       string
+		expectedError bool
+	}{
+		{"valid - unset", "", false},
+		{"valid", "5s", false},
+		{"invalid - unit", "5", true},
+		{"invalid - negative", "-5s", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{CommandTimeout: tt.timeout}}
+				},
+			})
+			err := ValidateCommandTimeout(dic)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
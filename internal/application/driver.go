@@ -0,0 +1,184 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// timeoutError is an EdgeX error reported with the 504 Gateway Timeout status code,
+// which has no corresponding ErrKind in go-mod-core-contracts.
+type timeoutError struct {
+	kindedError
+}
+
+// Code returns the status code of this error.
+func (e timeoutError) Code() int {
+	return http.StatusGatewayTimeout
+}
+
+// ValidateCommandTimeout checks Device.CommandTimeout.
+func ValidateCommandTimeout(dic *di.Container) errors.EdgeX {
+	_, err := commandTimeout(container.ConfigurationFrom(dic.Get).Device.CommandTimeout)
+	return err
+}
+
+func commandTimeout(s string) (time.Duration, errors.EdgeX) {
+	if s == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout < 0 {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.CommandTimeout %s", s), err)
+	}
+	return timeout, nil
+}
+
+type readResult struct {
+	values []*sdkModels.CommandValue
+	err    error
+}
+
+// commandContext derives the context of a device command from the given parent. The
+// correlation ID is attached to it and, unless the parent already carries a deadline
+// (e.g. from the ds-timeout query parameter), the Device.CommandTimeout configuration
+// is applied.
+func commandContext(parent context.Context, correlationID string, dic *di.Container) (context.Context, context.CancelFunc) {
+	ctx := context.WithValue(parent, common.CorrelationHeader, correlationID) // nolint: staticcheck
+
+	// the timeout is validated at startup
+	timeout, err := commandTimeout(container.ConfigurationFrom(dic.Get).Device.CommandTimeout)
+	if _, ok := ctx.Deadline(); ok || err != nil || timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// readCommands executes the protocol-specific read operation, coalesced with the concurrent
+// reads of the same device when read batching is enabled and retried according to the
+// Device.Retry policy. The outcome is recorded by the device health tracker.
+func readCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, dic *di.Container) ([]*sdkModels.CommandValue, error) {
+	window, maxRequests, edgexErr := batchSettings(device, container.ConfigurationFrom(dic.Get).Device.ReadBatching)
+	if edgexErr != nil {
+		return nil, edgexErr
+	}
+
+	var values []*sdkModels.CommandValue
+	err := retry(ctx, device, dic, false, func() error {
+		var readErr error
+		if window > 0 {
+			values, readErr = batchers.read(ctx, device, reqs, window, maxRequests, dic)
+		} else {
+			values, readErr = executeRead(ctx, device, reqs, dic)
+		}
+		return readErr
+	})
+	health.record(device, err, dic)
+	return values, err
+}
+
+// executeRead calls the driver to read reqs. The ContextualProtocolDriver is preferred when
+// implemented, and the call is abandoned as soon as ctx is done so that a hung device never
+// blocks the caller beyond the command deadline. The command slot of the device limiter is
+// held until the driver actually returns.
+func executeRead(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, dic *di.Container) ([]*sdkModels.CommandValue, error) {
+	release, err := limiters.acquire(ctx, device, container.ConfigurationFrom(dic.Get).Device.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+	contextDriver := container.ContextualProtocolDriverFrom(dic.Get)
+	driver := container.ProtocolDriverFrom(dic.Get)
+	read := func() ([]*sdkModels.CommandValue, error) {
+		defer release()
+		if contextDriver != nil {
+			return contextDriver.HandleReadCommandsWithContext(ctx, device.Name, device.Protocols, reqs)
+		}
+		return driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	}
+	ch := make(chan readResult, 1)
+	go func() {
+		values, err := read()
+		ch <- readResult{values: values, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.values, res.err
+	}
+}
+
+// writeCommands executes the protocol-specific write operation, retried according to the
+// Device.Retry policy if the retries of writes are enabled. The outcome is recorded by the device health tracker.
+func writeCommands(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue, dic *di.Container) error {
+	err := retry(ctx, device, dic, true, func() error {
+		return executeWrite(ctx, device, reqs, params, dic)
+	})
+	health.record(device, err, dic)
+	return err
+}
+
+// executeWrite calls the driver to write params, see executeRead.
+func executeWrite(ctx context.Context, device models.Device, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue, dic *di.Container) error {
+	release, err := limiters.acquire(ctx, device, container.ConfigurationFrom(dic.Get).Device.Concurrency)
+	if err != nil {
+		return err
+	}
+	contextDriver := container.ContextualProtocolDriverFrom(dic.Get)
+	driver := container.ProtocolDriverFrom(dic.Get)
+	write := func() error {
+		defer release()
+		if contextDriver != nil {
+			return contextDriver.HandleWriteCommandsWithContext(ctx, device.Name, device.Protocols, reqs, params)
+		}
+		return driver.HandleWriteCommands(device.Name, device.Protocols, reqs, params)
+	}
+	ch := make(chan error, 1)
+	go func() {
+		ch <- write()
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-ch:
+		return err
+	}
+}
+
+// driverError wraps the error returned from the ProtocolDriver according to the state of ctx.
+// A passed deadline results in a 504 Gateway Timeout error, and a full command queue in a
+// 503 Service Unavailable error. The deadline of a batched read may pass before the one of
+// ctx expires, the batch being bound to the same or a later deadline.
+func driverError(ctx context.Context, errMsg string, err error) errors.EdgeX {
+	if _, ok := err.(queueFullError); ok {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg, err)
+	}
+	ctxErr := ctx.Err()
+	if ctxErr == nil && stdErrors.Is(err, context.DeadlineExceeded) {
+		ctxErr = context.DeadlineExceeded
+	}
+	switch ctxErr {
+	case context.DeadlineExceeded:
+		return timeoutError{kindedError{errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg+": deadline exceeded", err)}}
+	case context.Canceled:
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg+": request cancelled", err)
+	default:
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+	}
+}
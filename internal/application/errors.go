@@ -0,0 +1,22 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// kindedError is embedded by the error types which have to be told apart from the other
+// errors of the same kind. Unwrap exposes the embedded CommonEdgeX so that errors.Kind keeps
+// working on these types.
+type kindedError struct {
+	errors.CommonEdgeX
+}
+
+func (e kindedError) Unwrap() error {
+	return e.CommonEdgeX
+}
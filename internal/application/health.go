@@ -0,0 +1,215 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var health = &healthTracker{failureMap: make(map[string]int), probeMap: make(map[string]chan struct{})}
+
+// errNoHealthCheck is returned by checkHealth when the device can be probed neither with a
+// HealthChecker nor with a health-check resource.
+var errNoHealthCheck = stdErrors.New("no HealthChecker nor health-check resource")
+
+// StartHealthProbes starts probing the devices which are DOWN, e.g. since before the
+// service started, as no command is sent to them which could record their recovery.
+func StartHealthProbes(dic *di.Container) {
+	for _, device := range cache.Devices().All() {
+		if device.OperatingState == models.Down {
+			health.startProbe(device.Name, dic)
+		}
+	}
+}
+
+// healthTracker marks a device as DOWN after a number of consecutive driver failures,
+// and probes it in the background until it can be restored to UP.
+type healthTracker struct {
+	failureMap map[string]int
+	probeMap   map[string]chan struct{}
+	mutex      sync.Mutex
+}
+
+// record updates the consecutive failure count of the device with the outcome of a driver
+// command. Commands rejected by the device limiter, cancelled by the requester or failed
+// with a permanent error say nothing about the device health and are not counted.
+func (h *healthTracker) record(device models.Device, err error, dic *di.Container) {
+	info := container.ConfigurationFrom(dic.Get).Device.Health
+	if info.FailureThreshold <= 0 {
+		return
+	}
+	if err != nil {
+		if _, ok := err.(queueFullError); ok || stdErrors.Is(err, context.Canceled) || sdkModels.IsPermanentError(err) {
+			return
+		}
+	}
+	// the device responded to a partially successful read
+	var partial sdkModels.PartialReadError
+	if stdErrors.As(err, &partial) {
+		err = nil
+	}
+
+	h.mutex.Lock()
+	if err == nil {
+		delete(h.failureMap, device.Name)
+		h.mutex.Unlock()
+		return
+	}
+	h.failureMap[device.Name]++
+	failures := h.failureMap[device.Name]
+	if failures < info.FailureThreshold {
+		h.mutex.Unlock()
+		return
+	}
+	delete(h.failureMap, device.Name)
+	h.mutex.Unlock()
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Warnf("device %s failed %d consecutive commands, marking it as %s", device.Name, failures, models.Down)
+	go sdkCommon.UpdateOperatingState(device.Name, models.Down, lc, bootstrapContainer.DeviceClientFrom(dic.Get))
+	h.startProbe(device.Name, dic)
+}
+
+// startProbe starts probing the device in the background unless it is already probed.
+func (h *healthTracker) startProbe(deviceName string, dic *di.Container) {
+	info := container.ConfigurationFrom(dic.Get).Device.Health
+	if info.FailureThreshold <= 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.probeMap[deviceName]; ok {
+		return
+	}
+	stop := make(chan struct{})
+	h.probeMap[deviceName] = stop
+	go h.probe(deviceName, stop, dic)
+}
+
+// stopProbe stops probing the device and forgets its failures.
+func (h *healthTracker) stopProbe(deviceName string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.failureMap, deviceName)
+	if stop, ok := h.probeMap[deviceName]; ok {
+		close(stop)
+		delete(h.probeMap, deviceName)
+	}
+}
+
+func (h *healthTracker) probe(deviceName string, stop chan struct{}, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	info := container.ConfigurationFrom(dic.Get).Device.Health
+	interval, err := time.ParseDuration(info.ProbeInterval)
+	if err != nil || interval <= 0 {
+		lc.Errorf("invalid Device.Health.ProbeInterval %s, device %s will not be probed", info.ProbeInterval, deviceName)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		device, ok := cache.Devices().ForName(deviceName)
+		if !ok {
+			h.stopProbe(deviceName)
+			return
+		}
+		err := checkHealth(device, dic)
+		if err == errNoHealthCheck {
+			lc.Warnf("device %s cannot be probed: %v, it stays %s until updated", deviceName, err, models.Down)
+			h.endProbe(deviceName, stop)
+			return
+		}
+		if err != nil {
+			lc.Debugf("device %s is still unreachable: %v", deviceName, err)
+			continue
+		}
+
+		if !h.probing(deviceName, stop) {
+			// stopped meanwhile, e.g. the device was set UP by an operator
+			return
+		}
+
+		lc.Infof("device %s is reachable again, marking it as %s", deviceName, models.Up)
+		if err := sdkCommon.UpdateOperatingState(deviceName, models.Up, lc, bootstrapContainer.DeviceClientFrom(dic.Get)); err != nil {
+			// the device stays DOWN in core-metadata, the update is retried at the next probe
+			continue
+		}
+		h.endProbe(deviceName, stop)
+		return
+	}
+}
+
+// probing returns false if the probe of the device was stopped.
+func (h *healthTracker) probing(deviceName string, stop chan struct{}) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.probeMap[deviceName] == stop
+}
+
+// endProbe forgets the probe of the device, and returns false if it was stopped meanwhile.
+func (h *healthTracker) endProbe(deviceName string, stop chan struct{}) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.probeMap[deviceName] != stop {
+		return false
+	}
+	delete(h.probeMap, deviceName)
+	return true
+}
+
+// checkHealth probes the device with the HealthChecker of the driver if implemented, or
+// otherwise by reading the health-check resource, and returns errNoHealthCheck without
+// either of them.
+func checkHealth(device models.Device, dic *di.Container) error {
+	if checker := container.HealthCheckerFrom(dic.Get); checker != nil {
+		return checker.CheckHealth(device.Name, device.Protocols)
+	}
+
+	resourceName := container.ConfigurationFrom(dic.Get).Device.Health.ProbeResource
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.HealthResource); ok {
+		resourceName = v
+	}
+	if resourceName == "" {
+		return errNoHealthCheck
+	}
+	dr, ok := cache.Profiles().DeviceResource(device.ProfileName, resourceName)
+	if !ok {
+		return fmt.Errorf("health-check deviceResource %s not found", resourceName)
+	}
+
+	ctx, cancel := commandContext(context.Background(), uuid.NewString(), dic)
+	defer cancel()
+	req := sdkModels.CommandRequest{
+		DeviceResourceName: dr.Name,
+		Attributes:         dr.Attributes,
+		Type:               dr.Properties.ValueType,
+	}
+	_, err := executeRead(ctx, device, []sdkModels.CommandRequest{req}, dic)
+	return err
+}
@@ -0,0 +1,249 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func operatingStateUpdate(state string) interface{} {
+	return mock.MatchedBy(func(reqs []requests.UpdateDeviceRequest) bool {
+		return len(reqs) == 1 && reqs[0].Device.OperatingState != nil && *reqs[0].Device.OperatingState == state
+	})
+}
+
+func TestHealthTracker(t *testing.T) {
+	dic := mockDic()
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("Update", context.Background(), operatingStateUpdate(models.Down)).Return(nil, nil)
+	dcMock.On("Update", context.Background(), operatingStateUpdate(models.Up)).Return(nil, nil)
+	checker := &mocks.HealthChecker{}
+	checker.On("CheckHealth", testDevice.Name, mock.Anything).Return(stdErrors.New("unreachable")).Once()
+	checker.On("CheckHealth", testDevice.Name, mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		container.HealthCheckerName: func(get di.Get) interface{} {
+			return checker
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{Health: config.HealthInfo{FailureThreshold: 3, ProbeInterval: "10ms"}},
+			}
+		},
+	})
+
+	h := &healthTracker{failureMap: make(map[string]int), probeMap: make(map[string]chan struct{})}
+	driverErr := stdErrors.New("no response")
+	tests := []struct {
+		name             string
+		err              error
+		expectedFailures int
+	}{
+		{"first failure", driverErr, 1},
+		{"second failure", driverErr, 2},
+		{"success resets the count", nil, 0},
+		{"failure after success", driverErr, 1},
+		{"permanent error not counted", sdkModels.NewPermanentError(driverErr), 1},
+		{"cancellation not counted", context.Canceled, 1},
+		{"second failure again", driverErr, 2},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			h.record(testDevice, testCase.err, dic)
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			assert.Equal(t, testCase.expectedFailures, h.failureMap[testDevice.Name])
+			assert.Empty(t, h.probeMap)
+		})
+	}
+
+	// the threshold is reached, the device is marked as DOWN and probed until it recovers
+	h.record(testDevice, driverErr, dic)
+	require.Eventually(t, func() bool {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		return len(h.probeMap) == 0
+	}, time.Second, 5*time.Millisecond)
+	checker.AssertNumberOfCalls(t, "CheckHealth", 2)
+	dcMock.AssertCalled(t, "Update", context.Background(), operatingStateUpdate(models.Down))
+	dcMock.AssertCalled(t, "Update", context.Background(), operatingStateUpdate(models.Up))
+}
+
+func TestHealthTracker_StopProbe(t *testing.T) {
+	dic := mockDic()
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+
+	probed := make(chan struct{}, 1)
+	checker := &mocks.HealthChecker{}
+	checker.On("CheckHealth", testDevice.Name, mock.Anything).Return(stdErrors.New("unreachable")).Run(func(args mock.Arguments) {
+		select {
+		case probed <- struct{}{}:
+		default:
+		}
+	})
+	dic.Update(di.ServiceConstructorMap{
+		container.HealthCheckerName: func(get di.Get) interface{} {
+			return checker
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{Health: config.HealthInfo{FailureThreshold: 1, ProbeInterval: "5ms"}},
+			}
+		},
+	})
+
+	h := &healthTracker{failureMap: make(map[string]int), probeMap: make(map[string]chan struct{})}
+	h.startProbe(testDevice.Name, dic)
+	h.startProbe(testDevice.Name, dic)
+	assert.Len(t, h.probeMap, 1)
+	select {
+	case <-probed:
+	case <-time.After(time.Second):
+		require.Fail(t, "device not probed")
+	}
+
+	h.stopProbe(testDevice.Name)
+	assert.Empty(t, h.probeMap)
+}
+
+func TestStartHealthProbes(t *testing.T) {
+	dic := mockDic()
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+	downDevice := testDevice
+	downDevice.Name = "down-device"
+	downDevice.OperatingState = models.Down
+	require.NoError(t, cache.Devices().Add(downDevice))
+	defer func() {
+		_ = cache.Devices().RemoveByName(downDevice.Name)
+		health.stopProbe(downDevice.Name)
+	}()
+
+	probed := make(chan struct{}, 1)
+	checker := &mocks.HealthChecker{}
+	checker.On("CheckHealth", downDevice.Name, mock.Anything).Return(stdErrors.New("unreachable")).Run(func(args mock.Arguments) {
+		select {
+		case probed <- struct{}{}:
+		default:
+		}
+	})
+	dic.Update(di.ServiceConstructorMap{
+		container.HealthCheckerName: func(get di.Get) interface{} {
+			return checker
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{Health: config.HealthInfo{FailureThreshold: 1, ProbeInterval: "5ms"}},
+			}
+		},
+	})
+
+	StartHealthProbes(dic)
+	health.mutex.Lock()
+	_, downProbed := health.probeMap[downDevice.Name]
+	_, upProbed := health.probeMap[testDevice.Name]
+	health.mutex.Unlock()
+	assert.True(t, downProbed, "DOWN device not probed")
+	assert.False(t, upProbed, "UP device probed")
+	select {
+	case <-probed:
+	case <-time.After(time.Second):
+		require.Fail(t, "device not probed")
+	}
+}
+
+func TestHealthTracker_NoHealthCheck(t *testing.T) {
+	dic := mockDic()
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+
+	dcMock := &clientMocks.DeviceClient{}
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{Health: config.HealthInfo{FailureThreshold: 1, ProbeInterval: "5ms"}},
+			}
+		},
+	})
+
+	// without a HealthChecker nor a health-check resource the probe gives up, leaving the device DOWN
+	h := &healthTracker{failureMap: make(map[string]int), probeMap: make(map[string]chan struct{})}
+	h.startProbe(testDevice.Name, dic)
+	require.Eventually(t, func() bool {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		return len(h.probeMap) == 0
+	}, time.Second, 5*time.Millisecond)
+	dcMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestHealthTracker_UpdateFailure(t *testing.T) {
+	dic := mockDic()
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+
+	dcMock := &clientMocks.DeviceClient{}
+	// core-metadata is unreachable at first, then rejects the update, then accepts it
+	dcMock.On("Update", context.Background(), operatingStateUpdate(models.Up)).Return(nil, errors.NewCommonEdgeX(errors.KindServiceUnavailable, "unreachable", nil)).Once()
+	dcMock.On("Update", context.Background(), operatingStateUpdate(models.Up)).Return([]common.BaseResponse{{StatusCode: http.StatusNotFound}}, nil).Once()
+	dcMock.On("Update", context.Background(), operatingStateUpdate(models.Up)).Return([]common.BaseResponse{{StatusCode: http.StatusOK}}, nil)
+	checker := &mocks.HealthChecker{}
+	checker.On("CheckHealth", testDevice.Name, mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		container.HealthCheckerName: func(get di.Get) interface{} {
+			return checker
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device: config.DeviceInfo{Health: config.HealthInfo{FailureThreshold: 1, ProbeInterval: "5ms"}},
+			}
+		},
+	})
+
+	// the device is probed until it is marked as UP
+	h := &healthTracker{failureMap: make(map[string]int), probeMap: make(map[string]chan struct{})}
+	h.startProbe(testDevice.Name, dic)
+	require.Eventually(t, func() bool {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		return len(h.probeMap) == 0
+	}, time.Second, 5*time.Millisecond)
+	dcMock.AssertNumberOfCalls(t, "Update", 3)
+}
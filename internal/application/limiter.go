@@ -0,0 +1,182 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+)
+
+var limiters = &limiterRegistry{limiterMap: make(map[string]*limiter)}
+
+// queueFullError is returned when a command cannot be queued for a device because
+// the number of waiting commands has reached the configured queue depth.
+type queueFullError struct {
+	kindedError
+}
+
+// limiter bounds the number of commands concurrently sent to the ProtocolDriver for
+// one key. Commands exceeding the limit wait in FIFO order.
+type limiter struct {
+	key           string
+	maxConcurrent int
+	maxQueueDepth int
+	active        int
+	waiters       *list.List
+	mutex         sync.Mutex
+}
+
+type limiterRegistry struct {
+	limiterMap map[string]*limiter
+	mutex      sync.Mutex
+}
+
+// limiterSettings resolves the limiter key and limits of the device. The Device.Concurrency
+// configuration is overridden by the ds-concurrencykey, ds-maxconcurrent and ds-maxqueuedepth
+// protocol properties of the device.
+func limiterSettings(device models.Device, info config.ConcurrencyInfo) (key string, maxConcurrent int, maxQueueDepth int) {
+	key = device.Name
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.ConcurrencyKey); ok {
+		key = fmt.Sprintf("%s=%s", sdkCommon.ConcurrencyKey, v)
+	} else if v, ok := sdkCommon.ProtocolProperty(device, info.KeyProperty); ok {
+		key = fmt.Sprintf("%s=%s", info.KeyProperty, v)
+	}
+
+	maxConcurrent = info.MaxConcurrent
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.MaxConcurrent); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			maxConcurrent = n
+		}
+	}
+	maxQueueDepth = info.MaxQueueDepth
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.MaxQueueDepth); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			maxQueueDepth = n
+		}
+	}
+
+	return key, maxConcurrent, maxQueueDepth
+}
+
+// acquire waits for a free command slot of the device and returns the function to release it.
+// No limit is applied when the resolved maximum concurrency is not positive.
+func (r *limiterRegistry) acquire(ctx context.Context, device models.Device, info config.ConcurrencyInfo) (func(), error) {
+	key, maxConcurrent, maxQueueDepth := limiterSettings(device, info)
+	if maxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	r.mutex.Lock()
+	l, ok := r.limiterMap[key]
+	if !ok {
+		l = &limiter{key: key, waiters: list.New()}
+		r.limiterMap[key] = l
+	}
+	r.mutex.Unlock()
+
+	err := l.acquire(ctx, maxConcurrent, maxQueueDepth)
+	if err != nil {
+		return nil, err
+	}
+	return l.release, nil
+}
+
+// remove drops the limiter of the device if no command is in progress or waiting on it.
+func (r *limiterRegistry) remove(device models.Device, info config.ConcurrencyInfo) {
+	key, _, _ := limiterSettings(device, info)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	l, ok := r.limiterMap[key]
+	if !ok {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.active == 0 && l.waiters.Len() == 0 {
+		delete(r.limiterMap, key)
+	}
+}
+
+// update drops the limiter of the device, see remove, once its key or limits are changed by
+// the update of the device, so that it is rebuilt from the new settings.
+func (r *limiterRegistry) update(previous models.Device, device models.Device, info config.ConcurrencyInfo) {
+	previousKey, previousConcurrent, previousQueueDepth := limiterSettings(previous, info)
+	key, maxConcurrent, maxQueueDepth := limiterSettings(device, info)
+	if key != previousKey || maxConcurrent != previousConcurrent || maxQueueDepth != previousQueueDepth {
+		r.remove(previous, info)
+	}
+}
+
+func (l *limiter) acquire(ctx context.Context, maxConcurrent int, maxQueueDepth int) error {
+	l.mutex.Lock()
+	// the limits are refreshed on every acquisition so that changes of the configuration
+	// or the device protocol properties take effect without recreating the limiter
+	l.maxConcurrent = maxConcurrent
+	l.maxQueueDepth = maxQueueDepth
+	l.dispatch()
+	if l.active < l.maxConcurrent {
+		l.active++
+		l.mutex.Unlock()
+		return nil
+	}
+	if l.maxQueueDepth > 0 && l.waiters.Len() >= l.maxQueueDepth {
+		l.mutex.Unlock()
+		errMsg := fmt.Sprintf("command queue of %s is full (%d)", l.key, l.maxQueueDepth)
+		return queueFullError{kindedError{errors.NewCommonEdgeX(errors.KindServiceUnavailable, errMsg, nil)}}
+	}
+	ready := make(chan struct{})
+	element := l.waiters.PushBack(ready)
+	l.mutex.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		select {
+		case <-ready:
+			// the slot was handed over right before the cancellation, pass it on
+			l.mutex.Unlock()
+			l.release()
+		default:
+			l.waiters.Remove(element)
+			l.mutex.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.active--
+	l.dispatch()
+}
+
+// dispatch hands free slots over to the longest waiting commands.
+func (l *limiter) dispatch() {
+	for l.active < l.maxConcurrent {
+		front := l.waiters.Front()
+		if front == nil {
+			return
+		}
+		l.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		l.active++
+	}
+}
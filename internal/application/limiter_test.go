@@ -0,0 +1,158 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+)
+
+func TestLimiterSettings(t *testing.T) {
+	info := config.ConcurrencyInfo{MaxConcurrent: 1, MaxQueueDepth: 5, KeyProperty: "Port"}
+	tests := []struct {
+		name          string
+		protocols     map[string]models.ProtocolProperties
+		expectedKey   string
+		expectedMax   int
+		expectedQueue int
+	}{
+		{"device name", nil, "test-device", 1, 5},
+		{"key property", map[string]models.ProtocolProperties{"serial": {"Port": "/dev/ttyS0"}}, "Port=/dev/ttyS0", 1, 5},
+		{"reserved key overrides key property",
+			map[string]models.ProtocolProperties{"serial": {"Port": "/dev/ttyS0", sdkCommon.ConcurrencyKey: "bus1"}},
+			sdkCommon.ConcurrencyKey + "=bus1", 1, 5},
+		{"limit overrides",
+			map[string]models.ProtocolProperties{"other": {sdkCommon.MaxConcurrent: "2", sdkCommon.MaxQueueDepth: "0"}},
+			"test-device", 2, 0},
+		{"invalid limit override", map[string]models.ProtocolProperties{"other": {sdkCommon.MaxConcurrent: "x"}}, "test-device", 1, 5},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			device := models.Device{Name: "test-device", Protocols: testCase.protocols}
+			key, maxConcurrent, maxQueueDepth := limiterSettings(device, info)
+			assert.Equal(t, testCase.expectedKey, key)
+			assert.Equal(t, testCase.expectedMax, maxConcurrent)
+			assert.Equal(t, testCase.expectedQueue, maxQueueDepth)
+		})
+	}
+}
+
+func TestLimiter_FIFO(t *testing.T) {
+	l := &limiterRegistry{limiterMap: make(map[string]*limiter)}
+	device := models.Device{Name: "test-device"}
+	info := config.ConcurrencyInfo{MaxConcurrent: 1}
+
+	release, err := l.acquire(context.Background(), device, info)
+	require.NoError(t, err)
+
+	order := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		i := i
+		go func() {
+			r, err := l.acquire(context.Background(), device, info)
+			require.NoError(t, err)
+			order <- i
+			r()
+		}()
+		// wait until the command is queued so that the arrival order is known
+		require.Eventually(t, func() bool {
+			lim := l.limiterMap[device.Name]
+			lim.mutex.Lock()
+			defer lim.mutex.Unlock()
+			return lim.waiters.Len() == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	release()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i, <-order)
+	}
+}
+
+func TestLimiter_QueueFull(t *testing.T) {
+	l := &limiterRegistry{limiterMap: make(map[string]*limiter)}
+	device := models.Device{Name: "test-device"}
+	info := config.ConcurrencyInfo{MaxConcurrent: 1, MaxQueueDepth: 1}
+
+	release, err := l.acquire(context.Background(), device, info)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error)
+	go func() {
+		_, err := l.acquire(ctx, device, info)
+		queued <- err
+	}()
+	require.Eventually(t, func() bool {
+		lim := l.limiterMap[device.Name]
+		lim.mutex.Lock()
+		defer lim.mutex.Unlock()
+		return lim.waiters.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	_, err = l.acquire(context.Background(), device, info)
+	require.Error(t, err)
+	_, ok := err.(queueFullError)
+	assert.True(t, ok)
+	assert.Equal(t, errors.KindServiceUnavailable, errors.Kind(err))
+
+	// the cancelled command leaves the queue without taking the slot
+	cancel()
+	assert.ErrorIs(t, <-queued, context.Canceled)
+	lim := l.limiterMap[device.Name]
+	assert.Equal(t, 0, lim.waiters.Len())
+	assert.Equal(t, 1, lim.active)
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	l := &limiterRegistry{limiterMap: make(map[string]*limiter)}
+	device := models.Device{Name: "test-device"}
+
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire(context.Background(), device, config.ConcurrencyInfo{})
+		require.NoError(t, err)
+	}
+	assert.Empty(t, l.limiterMap)
+}
+
+func TestLimiter_Update(t *testing.T) {
+	info := config.ConcurrencyInfo{MaxConcurrent: 1}
+	device := models.Device{Name: "test-device", Protocols: map[string]models.ProtocolProperties{"other": {sdkCommon.ConcurrencyKey: "bus1"}}}
+	tests := []struct {
+		name      string
+		protocols map[string]models.ProtocolProperties
+		removed   bool
+	}{
+		{"unchanged", map[string]models.ProtocolProperties{"other": {sdkCommon.ConcurrencyKey: "bus1"}}, false},
+		{"key changed", map[string]models.ProtocolProperties{"other": {sdkCommon.ConcurrencyKey: "bus2"}}, true},
+		{"limit changed", map[string]models.ProtocolProperties{"other": {sdkCommon.ConcurrencyKey: "bus1", sdkCommon.MaxConcurrent: "0"}}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			l := &limiterRegistry{limiterMap: make(map[string]*limiter)}
+			release, err := l.acquire(context.Background(), device, info)
+			require.NoError(t, err)
+			release()
+
+			updated := device
+			updated.Protocols = testCase.protocols
+			l.update(device, updated, info)
+			_, ok := l.limiterMap[sdkCommon.ConcurrencyKey+"=bus1"]
+			assert.Equal(t, !testCase.removed, ok)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	stdErrors "errors"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// partialResults separates the failed device resources of a partially successful read from
+// its values. The read is considered failed as a whole if no value was read at all.
+func partialResults(values []*sdkModels.CommandValue, err error) ([]*sdkModels.CommandValue, map[string]string, error) {
+	var partial sdkModels.PartialReadError
+	if !stdErrors.As(err, &partial) {
+		return values, nil, err
+	}
+
+	readValues := make([]*sdkModels.CommandValue, 0, len(values))
+	for _, cv := range values {
+		if cv != nil {
+			readValues = append(readValues, cv)
+		}
+	}
+	if len(readValues) == 0 {
+		return nil, nil, err
+	}
+
+	failures := make(map[string]string, len(partial.Errors))
+	for name, e := range partial.Errors {
+		failures[name] = e.Error()
+	}
+	return readValues, failures, nil
+}
+
+// tagFailedResources lists the failed device resources, along with their error, in the
+// ds-failedresources tag of the event.
+func tagFailedResources(event *dtos.Event, failures map[string]string) {
+	if event == nil || len(failures) == 0 {
+		return
+	}
+	if event.Tags == nil {
+		event.Tags = make(map[string]interface{})
+	}
+	event.Tags[sdkCommon.FailedResources] = failures
+}
+
+// FailedResources returns the device resources listed in the ds-failedresources tag of
+// the event of a partially successful GET command, sorted by name.
+func FailedResources(event *dtos.Event) []sdkDTOs.FailedResource {
+	if event == nil {
+		return nil
+	}
+	failures, ok := event.Tags[sdkCommon.FailedResources].(map[string]string)
+	if !ok {
+		return nil
+	}
+	result := make([]sdkDTOs.FailedResource, 0, len(failures))
+	for name, msg := range failures {
+		result = append(result, sdkDTOs.FailedResource{DeviceResource: name, Message: msg})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DeviceResource < result[j].DeviceResource
+	})
+	return result
+}
@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestPartialResults(t *testing.T) {
+	cv, _ := sdkModels.NewCommandValue("r1", common.ValueTypeInt16, int16(1))
+	readErr := stdErrors.New("no response")
+	partialErr := sdkModels.NewPartialReadError(map[string]error{"r2": readErr})
+
+	tests := []struct {
+		name             string
+		values           []*sdkModels.CommandValue
+		err              error
+		expectedValues   []*sdkModels.CommandValue
+		expectedFailures map[string]string
+		expectedErr      error
+	}{
+		{"success", []*sdkModels.CommandValue{cv}, nil, []*sdkModels.CommandValue{cv}, nil, nil},
+		{"failure", nil, readErr, nil, nil, readErr},
+		{"partial success", []*sdkModels.CommandValue{cv, nil}, partialErr, []*sdkModels.CommandValue{cv}, map[string]string{"r2": readErr.Error()}, nil},
+		{"partial error without values", []*sdkModels.CommandValue{nil}, partialErr, nil, nil, partialErr},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			values, failures, err := partialResults(testCase.values, testCase.err)
+			assert.Equal(t, testCase.expectedValues, values)
+			assert.Equal(t, testCase.expectedFailures, failures)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPartialBatchResult(t *testing.T) {
+	r1 := sdkModels.CommandRequest{DeviceResourceName: "r1"}
+	r2 := sdkModels.CommandRequest{DeviceResourceName: "r2"}
+	v1, _ := sdkModels.NewCommandValue("r1", common.ValueTypeInt16, int16(1))
+	partial := sdkModels.PartialReadError{Errors: map[string]error{"r2": stdErrors.New("no response")}}
+
+	values, err := partialBatchResult([]sdkModels.CommandRequest{r1}, []*sdkModels.CommandValue{v1}, partial)
+	assert.NoError(t, err)
+	assert.Equal(t, []*sdkModels.CommandValue{v1}, values)
+
+	values, err = partialBatchResult([]sdkModels.CommandRequest{r1, r2}, []*sdkModels.CommandValue{v1}, partial)
+	var partialErr sdkModels.PartialReadError
+	require.True(t, stdErrors.As(err, &partialErr))
+	assert.Contains(t, partialErr.Errors, "r2")
+	assert.Equal(t, []*sdkModels.CommandValue{v1}, values)
+}
+
+func TestCommandProcessor_ReadDeviceCommand_Partial(t *testing.T) {
+	rw := sdkModels.CommandRequest{DeviceResourceName: "test-resource", Type: common.ValueTypeString}
+	ro := sdkModels.CommandRequest{DeviceResourceName: "ro-resource", Type: common.ValueTypeString}
+	cv, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "test-value")
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", "test-device", testProtocols, []sdkModels.CommandRequest{rw, ro}).
+		Return([]*sdkModels.CommandValue{cv, nil}, sdkModels.NewPartialReadError(map[string]error{"ro-resource": stdErrors.New("no response")}))
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Device: config.DeviceInfo{MaxCmdOps: 2}}
+		},
+	})
+	err := cache.InitCache("test-service", dic)
+	require.NoError(t, err)
+
+	event, err := NewCommandProcessor(context.Background(), testDevice, "exceed-command", uuid.NewString(), nil, "", dic).ReadDeviceCommand()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	require.Len(t, event.Readings, 1)
+	assert.Equal(t, "test-resource", event.Readings[0].ResourceName)
+	assert.Equal(t, map[string]string{"ro-resource": "no response"}, event.Tags[sdkCommon.FailedResources])
+}
+
+func TestFailedResources(t *testing.T) {
+	event := dtos.NewEvent("test-profile", "test-device", "test-command")
+	assert.Nil(t, FailedResources(nil))
+	assert.Nil(t, FailedResources(&event))
+
+	tagFailedResources(&event, map[string]string{"r2": "no response", "r1": "timeout"})
+	expected := []sdkDTOs.FailedResource{
+		{DeviceResource: "r1", Message: "timeout"},
+		{DeviceResource: "r2", Message: "no response"},
+	}
+	assert.Equal(t, expected, FailedResources(&event))
+}
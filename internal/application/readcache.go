@@ -0,0 +1,206 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var readCache = &valueCache{deviceMap: make(map[string]map[string]cachedValue)}
+
+type maxAgeKey struct{}
+
+// WithMaxAge returns a copy of ctx in which GET commands may be served from the last
+// CommandValue of a device resource if it is not older than maxAge. It overrides the
+// ds-maxage attribute of the device resources, and 0 bypasses the read cache.
+func WithMaxAge(ctx context.Context, maxAge time.Duration) context.Context {
+	return context.WithValue(ctx, maxAgeKey{}, maxAge)
+}
+
+// valueCache keeps the last CommandValue read from each device resource.
+type valueCache struct {
+	deviceMap map[string]map[string]cachedValue
+	// version is incremented by every invalidation, whose version is kept per device in
+	// invalidated, or in dropped when all the values of the device are dropped, so that the
+	// values of the reads started before an invalidation are not put back in the cache
+	version     uint64
+	invalidated map[string]uint64
+	dropped     uint64
+	mutex       sync.RWMutex
+}
+
+type cachedValue struct {
+	value *sdkModels.CommandValue
+	// origin is the time the value was read in nanoseconds, taken from CommandValue.Origin
+	// unless the driver left it unset
+	origin int64
+}
+
+// get returns a copy of the cached value of the device resource if it is younger than maxAge.
+func (c *valueCache) get(deviceName string, resourceName string, maxAge time.Duration) (*sdkModels.CommandValue, bool) {
+	if maxAge <= 0 {
+		return nil, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	cached, ok := c.deviceMap[deviceName][resourceName]
+	if !ok || time.Now().UnixNano()-cached.origin > maxAge.Nanoseconds() {
+		return nil, false
+	}
+	return copyCommandValue(cached.value), true
+}
+
+// currentVersion returns the version to pass to put for the values of a read starting now.
+func (c *valueCache) currentVersion() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.version
+}
+
+// put stores a copy of the values read from the device, unless the cached values of the
+// device were invalidated since version was taken, e.g. by a SET command completed while
+// the read was in progress.
+func (c *valueCache) put(deviceName string, version uint64, values []*sdkModels.CommandValue) {
+	now := time.Now().UnixNano()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	invalidated, ok := c.invalidated[deviceName]
+	if !ok {
+		invalidated = c.dropped
+	}
+	if invalidated > version {
+		return
+	}
+	resources, ok := c.deviceMap[deviceName]
+	if !ok {
+		resources = make(map[string]cachedValue)
+		c.deviceMap[deviceName] = resources
+	}
+	for _, cv := range values {
+		if cv == nil {
+			continue
+		}
+		// a streamed value can only be read once
+		if _, ok := cv.BinaryStream(); ok {
+			continue
+		}
+		origin := cv.Origin
+		if origin == 0 {
+			origin = now
+		}
+		resources[cv.DeviceResourceName] = cachedValue{value: copyCommandValue(cv), origin: origin}
+	}
+}
+
+// invalidate drops the cached values of the given resources of the device, or of all its
+// resources if none is given.
+func (c *valueCache) invalidate(deviceName string, resourceNames ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.version++
+	if len(resourceNames) == 0 {
+		delete(c.deviceMap, deviceName)
+		delete(c.invalidated, deviceName)
+		c.dropped = c.version
+		return
+	}
+	if c.invalidated == nil {
+		c.invalidated = make(map[string]uint64)
+	}
+	c.invalidated[deviceName] = c.version
+	for _, name := range resourceNames {
+		delete(c.deviceMap[deviceName], name)
+	}
+}
+
+// maxAge resolves the max age of the read cache for the request, the ds-maxage query
+// parameter taking precedence over the ds-maxage attribute of the device resource.
+func maxAge(ctx context.Context, req sdkModels.CommandRequest) time.Duration {
+	if maxAge, ok := ctx.Value(maxAgeKey{}).(time.Duration); ok {
+		return maxAge
+	}
+	v, ok := req.Attributes[sdkCommon.MaxAge]
+	if !ok {
+		return 0
+	}
+	maxAge, err := time.ParseDuration(fmt.Sprint(v))
+	if err != nil {
+		return 0
+	}
+	return maxAge
+}
+
+// readWithCache serves the requests with a fresh enough cached value from the read cache
+// and reads the others from the device, caching the results. Requests carrying additional
+// query parameters always go to the device since the parameters may alter the result.
+func (c *CommandProcessor) readWithCache(reqs []sdkModels.CommandRequest) ([]*sdkModels.CommandValue, error) {
+	if c.attributes != "" {
+		return readCommands(c.ctx, c.device, reqs, c.dic)
+	}
+
+	results := make([]*sdkModels.CommandValue, len(reqs))
+	var missingReqs []sdkModels.CommandRequest
+	var missingIndexes []int
+	for i, req := range reqs {
+		if cv, ok := readCache.get(c.device.Name, req.DeviceResourceName, maxAge(c.ctx, req)); ok {
+			results[i] = cv
+		} else {
+			missingReqs = append(missingReqs, req)
+			missingIndexes = append(missingIndexes, i)
+		}
+	}
+	if len(missingReqs) == 0 {
+		return results, nil
+	}
+
+	version := readCache.currentVersion()
+	values, err := readCommands(c.ctx, c.device, missingReqs, c.dic)
+	var partial sdkModels.PartialReadError
+	isPartial := stdErrors.As(err, &partial)
+	if err != nil && !isPartial {
+		return nil, err
+	}
+	readCache.put(c.device.Name, version, values)
+	if len(missingReqs) == len(reqs) {
+		return values, err
+	}
+	if isPartial {
+		// the values of a partial read are matched by name, and merged in the order of the requests
+		read := make(map[string]*sdkModels.CommandValue, len(values))
+		for _, cv := range values {
+			if cv != nil {
+				read[cv.DeviceResourceName] = cv
+			}
+		}
+		merged := make([]*sdkModels.CommandValue, 0, len(reqs))
+		for i, index := range missingIndexes {
+			results[index] = read[missingReqs[i].DeviceResourceName]
+		}
+		for _, cv := range results {
+			if cv != nil {
+				merged = append(merged, cv)
+			}
+		}
+		return merged, err
+	}
+	if len(values) != len(missingReqs) {
+		return nil, fmt.Errorf("expected %d CommandValues from the read of %s, got %d", len(missingReqs), c.device.Name, len(values))
+	}
+	for i, index := range missingIndexes {
+		results[index] = values[i]
+	}
+	return results, nil
+}
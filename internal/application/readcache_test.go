@@ -0,0 +1,154 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestValueCache(t *testing.T) {
+	c := &valueCache{deviceMap: make(map[string]map[string]cachedValue)}
+	fresh, _ := sdkModels.NewCommandValue("fresh", common.ValueTypeInt16, int16(1))
+	stale, _ := sdkModels.NewCommandValue("stale", common.ValueTypeInt16, int16(2))
+	stale.Origin = time.Now().Add(-time.Minute).UnixNano()
+	c.put("test-device", c.currentVersion(), []*sdkModels.CommandValue{fresh, stale})
+
+	tests := []struct {
+		name         string
+		resourceName string
+		maxAge       time.Duration
+		expectedHit  bool
+	}{
+		{"fresh value", "fresh", time.Second, true},
+		{"stale value", "stale", time.Second, false},
+		{"stale value within max age", "stale", time.Hour, true},
+		{"cache bypassed", "fresh", 0, false},
+		{"unknown resource", "unknown", time.Hour, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			cv, ok := c.get("test-device", testCase.resourceName, testCase.maxAge)
+			assert.Equal(t, testCase.expectedHit, ok)
+			if ok {
+				assert.Equal(t, testCase.resourceName, cv.DeviceResourceName)
+			}
+		})
+	}
+
+	// the cached value is not affected by the in-place transformation of the returned copy
+	cv, ok := c.get("test-device", "fresh", time.Second)
+	require.True(t, ok)
+	cv.Value = int16(10)
+	cv, ok = c.get("test-device", "fresh", time.Second)
+	require.True(t, ok)
+	assert.Equal(t, int16(1), cv.Value)
+
+	c.invalidate("test-device", "fresh")
+	_, ok = c.get("test-device", "fresh", time.Hour)
+	assert.False(t, ok)
+	_, ok = c.get("test-device", "stale", time.Hour)
+	assert.True(t, ok)
+
+	c.invalidate("test-device")
+	_, ok = c.get("test-device", "stale", time.Hour)
+	assert.False(t, ok)
+}
+
+func TestValueCache_StalePut(t *testing.T) {
+	c := &valueCache{deviceMap: make(map[string]map[string]cachedValue)}
+	cv, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeInt16, int16(1))
+
+	tests := []struct {
+		name        string
+		invalidate  func()
+		expectedHit bool
+	}{
+		{"no invalidation", func() {}, true},
+		{"resource invalidated", func() { c.invalidate("test-device", "test-resource") }, false},
+		{"device invalidated", func() { c.invalidate("test-device") }, false},
+		{"other device invalidated", func() { c.invalidate("other-device", "test-resource") }, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c.invalidate("test-device")
+			// the read starts, then the invalidation happens before its values are put
+			version := c.currentVersion()
+			testCase.invalidate()
+			c.put("test-device", version, []*sdkModels.CommandValue{cv})
+			_, ok := c.get("test-device", "test-resource", time.Hour)
+			assert.Equal(t, testCase.expectedHit, ok)
+		})
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	withAttribute := sdkModels.CommandRequest{Attributes: map[string]interface{}{sdkCommon.MaxAge: "5s"}}
+	invalidAttribute := sdkModels.CommandRequest{Attributes: map[string]interface{}{sdkCommon.MaxAge: "abc"}}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      sdkModels.CommandRequest
+		expected time.Duration
+	}{
+		{"no max age", context.Background(), sdkModels.CommandRequest{}, 0},
+		{"resource attribute", context.Background(), withAttribute, 5 * time.Second},
+		{"invalid resource attribute", context.Background(), invalidAttribute, 0},
+		{"query parameter", WithMaxAge(context.Background(), time.Second), sdkModels.CommandRequest{}, time.Second},
+		{"query parameter overrides attribute", WithMaxAge(context.Background(), 0), withAttribute, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, maxAge(testCase.ctx, testCase.req))
+		})
+	}
+}
+
+func TestCommandProcessor_ReadWithCache_PartialOrder(t *testing.T) {
+	reqs := make([]sdkModels.CommandRequest, 4)
+	values := make([]*sdkModels.CommandValue, 4)
+	for i, name := range []string{"first", "cached", "failed", "last"} {
+		reqs[i] = sdkModels.CommandRequest{DeviceResourceName: name, Type: common.ValueTypeInt16}
+		values[i], _ = sdkModels.NewCommandValue(name, common.ValueTypeInt16, int16(i))
+	}
+	readCache.invalidate(testDevice.Name)
+	defer readCache.invalidate(testDevice.Name)
+	readCache.put(testDevice.Name, readCache.currentVersion(), []*sdkModels.CommandValue{values[1]})
+
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", testDevice.Name, testProtocols, []sdkModels.CommandRequest{reqs[0], reqs[2], reqs[3]}).
+		Return([]*sdkModels.CommandValue{values[0], nil, values[3]}, sdkModels.NewPartialReadError(map[string]error{"failed": stdErrors.New("no response")}))
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+
+	ctx := WithMaxAge(context.Background(), time.Hour)
+	results, err := NewCommandProcessor(ctx, testDevice, "test-command", uuid.NewString(), nil, "", dic).readWithCache(reqs)
+	var partial sdkModels.PartialReadError
+	require.True(t, stdErrors.As(err, &partial))
+	require.Len(t, results, 3)
+	for i, name := range []string{"first", "cached", "last"} {
+		assert.Equal(t, name, results[i].DeviceResourceName)
+	}
+}
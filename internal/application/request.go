@@ -0,0 +1,115 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// CommandResult is the outcome of a device command requested through the REST API or the MessageBus.
+type CommandResult struct {
+	Event *dtos.Event
+	// Transaction is the transaction of a SET command executed with ds-transaction=yes
+	Transaction *Transaction
+	// ReturnEvent reports whether the event is expected in the response (ds-returnevent)
+	ReturnEvent bool
+	Err         errors.EdgeX
+}
+
+// ExecuteCommand executes the device command identified by vars, applying the SDK reserved
+// query parameters found in rawQuery while the other ones are passed to the driver as attributes.
+func ExecuteCommand(ctx context.Context, isRead bool, correlationID string, vars map[string]string, requestParamsMap map[string]interface{}, rawQuery string, dic *di.Container) (result CommandResult) {
+	// parse query parameter
+	queryParams, reserved, err := filterQueryParams(rawQuery)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	// return event in http response if specified (default yes)
+	if ok, exist := reserved[common.ReturnEvent]; !exist || ok[0] == common.ValueYes {
+		result.ReturnEvent = true
+	}
+
+	var sendEvent bool
+	// push event to CoreData if specified (default no)
+	if ok, exist := reserved[common.PushEvent]; exist && ok[0] == common.ValueYes {
+		sendEvent = true
+	}
+	// the request context is cancelled when the client disconnects, and it carries
+	// the command deadline if specified (default Device.CommandTimeout)
+	if timeout, exist := reserved[sdkCommon.Timeout]; exist {
+		duration, parseErr := time.ParseDuration(timeout[0])
+		if parseErr != nil || duration <= 0 {
+			result.Err = errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s value %s", sdkCommon.Timeout, timeout[0]), parseErr)
+			return result
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	// a reading younger than the max age can be served from the read cache
+	if maxAge, exist := reserved[sdkCommon.MaxAge]; exist {
+		duration, parseErr := time.ParseDuration(maxAge[0])
+		if parseErr != nil || duration < 0 {
+			result.Err = errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s value %s", sdkCommon.MaxAge, maxAge[0]), parseErr)
+			return result
+		}
+		ctx = WithMaxAge(ctx, duration)
+	}
+
+	// the written values are read back and compared if specified (default per device command
+	// or device resource)
+	if verify, exist := reserved[sdkCommon.Verify]; exist {
+		enabled, parseErr := parseVerify(verify[0])
+		if parseErr != nil {
+			result.Err = errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s value %s", sdkCommon.Verify, verify[0]), parseErr)
+			return result
+		}
+		ctx = WithVerify(ctx, enabled)
+	}
+
+	// the resources are written as a transaction if specified (default no)
+	if ok, exist := reserved[sdkCommon.Transaction]; exist && ok[0] == common.ValueYes && !isRead {
+		ctx, result.Transaction = WithTransaction(ctx)
+	}
+
+	result.Event, result.Err = CommandHandler(ctx, isRead, sendEvent, correlationID, vars, requestParamsMap, queryParams, dic)
+	return result
+}
+
+// filterQueryParams separates the SDK reserved query parameters from the ones passed to the driver.
+func filterQueryParams(queryParams string) (string, url.Values, errors.EdgeX) {
+	m, err := url.ParseQuery(queryParams)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServerError, "failed to parse query parameter", err)
+		return "", nil, edgexErr
+	}
+
+	var reserved = make(url.Values)
+	// Separate parameters with SDK reserved prefix
+	for k := range m {
+		if strings.HasPrefix(k, sdkCommon.SDKReservedPrefix) {
+			reserved.Set(k, m.Get(k))
+			delete(m, k)
+		}
+	}
+
+	return m.Encode(), reserved, nil
+}
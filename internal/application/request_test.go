@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestFilterQueryParams(t *testing.T) {
+	query := url.Values{}
+	query.Set("attr", "value")
+	query.Set(sdkCommon.Timeout, "1s")
+	query.Set(common.ReturnEvent, common.ValueNo)
+
+	queryParams, reserved, err := filterQueryParams(query.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, "attr=value", queryParams)
+	assert.Equal(t, url.Values{sdkCommon.Timeout: {"1s"}, common.ReturnEvent: {common.ValueNo}}, reserved)
+
+	_, _, err = filterQueryParams("%zz")
+	assert.Error(t, err)
+}
+
+func TestExecuteCommand_InvalidReservedParams(t *testing.T) {
+	vars := map[string]string{common.Name: testDevice.Name, common.Command: "test-resource"}
+	tests := []struct {
+		name     string
+		rawQuery string
+	}{
+		{"invalid timeout", sdkCommon.Timeout + "=abc"},
+		{"non-positive timeout", sdkCommon.Timeout + "=0s"},
+		{"invalid max age", sdkCommon.MaxAge + "=abc"},
+		{"negative max age", sdkCommon.MaxAge + "=-1s"},
+		{"invalid verify", sdkCommon.Verify + "=maybe"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			res := ExecuteCommand(context.Background(), true, uuid.NewString(), vars, nil, testCase.rawQuery, mockDic())
+			require.Error(t, res.Err)
+			assert.Equal(t, errors.KindContractInvalid, errors.Kind(res.Err))
+			assert.Nil(t, res.Event)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// retry runs op until it succeeds, fails with an error which is not retryable, uses up the
+// attempts of the Device.Retry policy or ctx is done, and returns the last error of op.
+// The delay between attempts grows exponentially from BaseDelay up to MaxDelay. A write op
+// is only retried if Device.Retry.Writes is enabled, and never after a timeout.
+func retry(ctx context.Context, device models.Device, dic *di.Container, write bool, op func() error) error {
+	info := container.ConfigurationFrom(dic.Get).Device.Retry
+	if info.MaxAttempts <= 1 || (write && !info.Writes) {
+		return op()
+	}
+	// the delays are validated at startup
+	baseDelay, maxDelay, _ := retryDelays(info)
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= info.MaxAttempts || !retryable(err, info.RetryableKinds) || (write && timedOut(err)) {
+			return err
+		}
+
+		delay := backoff(attempt, baseDelay, maxDelay, info.Jitter)
+		lc.Debugf("attempt %d of command for device %s failed, retrying in %s: %v", attempt, device.Name, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// ValidateRetry checks the delays of Device.Retry.
+func ValidateRetry(dic *di.Container) errors.EdgeX {
+	_, _, err := retryDelays(container.ConfigurationFrom(dic.Get).Device.Retry)
+	return err
+}
+
+func retryDelays(info config.RetryInfo) (baseDelay time.Duration, maxDelay time.Duration, edgexErr errors.EdgeX) {
+	var err error
+	if info.BaseDelay != "" {
+		baseDelay, err = time.ParseDuration(info.BaseDelay)
+		if err != nil || baseDelay < 0 {
+			errMsg := fmt.Sprintf("invalid Device.Retry.BaseDelay %s", info.BaseDelay)
+			return 0, 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+		}
+	}
+	if info.MaxDelay != "" {
+		maxDelay, err = time.ParseDuration(info.MaxDelay)
+		if err != nil || maxDelay < 0 {
+			errMsg := fmt.Sprintf("invalid Device.Retry.MaxDelay %s", info.MaxDelay)
+			return 0, 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
+		}
+	}
+	return baseDelay, maxDelay, nil
+}
+
+// retryable reports whether a failed command should be attempted again. The classification
+// of the driver takes precedence over the configured retryable error kinds, a driver error
+// which is not an EdgeX error being of the Unknown kind, and commands
+// which are cancelled, timed out, rejected by the device limiter or partially successful
+// are never retried.
+func retryable(err error, kinds []string) bool {
+	if stdErrors.Is(err, context.Canceled) || stdErrors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if _, ok := err.(queueFullError); ok {
+		return false
+	}
+	var partial sdkModels.PartialReadError
+	if sdkModels.IsPermanentError(err) || stdErrors.As(err, &partial) {
+		return false
+	}
+	if sdkModels.IsTransientError(err) || len(kinds) == 0 {
+		return true
+	}
+
+	kind := string(errors.Kind(err))
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// timedOut reports whether err is a timeout, in which case the driver operation may still
+// be in progress or applied by the device.
+func timedOut(err error) bool {
+	if stdErrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return stdErrors.As(err, &timeout) && timeout.Timeout()
+}
+
+// backoff returns the delay before the next attempt, which is reduced by a random
+// fraction of up to jitter so that concurrent commands do not retry in lockstep.
+func backoff(attempt int, baseDelay time.Duration, maxDelay time.Duration, jitter float64) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempt && (maxDelay <= 0 || delay < maxDelay); i++ {
+		delay *= 2
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	if jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(float64(delay) * jitter * rand.Float64()) // nolint: gosec
+	}
+	return delay
+}
@@ -0,0 +1,146 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestRetryable(t *testing.T) {
+	communicationErr := errors.NewCommonEdgeX(errors.KindCommunicationError, "bus error", nil)
+	tests := []struct {
+		name     string
+		err      error
+		kinds    []string
+		expected bool
+	}{
+		{"unclassified, any kind", stdErrors.New("failure"), nil, true},
+		{"unclassified, retryable kind", communicationErr, []string{string(errors.KindCommunicationError)}, true},
+		{"unclassified, not retryable kind", stdErrors.New("failure"), []string{string(errors.KindCommunicationError)}, false},
+		{"unclassified, not an EdgeX error", stdErrors.New("failure"), []string{string(errors.KindUnknown)}, true},
+		{"transient", sdkModels.NewTransientError(stdErrors.New("failure")), []string{string(errors.KindCommunicationError)}, true},
+		{"permanent", sdkModels.NewPermanentError(communicationErr), nil, false},
+		{"deadline exceeded", context.DeadlineExceeded, nil, false},
+		{"queue full", queueFullError{kindedError{errors.NewCommonEdgeX(errors.KindServiceUnavailable, "full", nil)}}, nil, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, retryable(testCase.err, testCase.kinds))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		maxDelay time.Duration
+		expected time.Duration
+	}{
+		{"first retry", 1, 0, 100 * time.Millisecond},
+		{"third retry", 3, 0, 400 * time.Millisecond},
+		{"capped", 10, time.Second, time.Second},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, backoff(testCase.attempt, 100*time.Millisecond, testCase.maxDelay, 0))
+		})
+	}
+
+	delay := backoff(1, 100*time.Millisecond, 0, 0.5)
+	assert.True(t, delay > 50*time.Millisecond && delay <= 100*time.Millisecond)
+}
+
+func TestRetry(t *testing.T) {
+	transientErr := sdkModels.NewTransientError(stdErrors.New("transient"))
+	permanentErr := sdkModels.NewPermanentError(stdErrors.New("permanent"))
+	timeoutErr := sdkModels.NewTransientError(os.ErrDeadlineExceeded)
+	tests := []struct {
+		name             string
+		maxAttempts      int
+		write            bool
+		retryWrites      bool
+		failures         []error
+		expectedErr      error
+		expectedAttempts int
+	}{
+		{"no retry policy", 0, false, false, []error{transientErr}, transientErr, 1},
+		{"succeed after retries", 3, false, false, []error{transientErr, transientErr}, nil, 3},
+		{"attempts used up", 2, false, false, []error{transientErr, transientErr}, transientErr, 2},
+		{"permanent error", 3, false, false, []error{permanentErr}, permanentErr, 1},
+		{"read timeout", 3, false, false, []error{timeoutErr}, nil, 2},
+		{"write, retries of writes disabled", 3, true, false, []error{transientErr}, transientErr, 1},
+		{"write, retries of writes enabled", 3, true, true, []error{transientErr}, nil, 2},
+		{"write timeout", 3, true, true, []error{timeoutErr}, timeoutErr, 1},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{
+						Device: config.DeviceInfo{Retry: config.RetryInfo{MaxAttempts: testCase.maxAttempts, BaseDelay: "1ms", Writes: testCase.retryWrites}},
+					}
+				},
+			})
+
+			attempts := 0
+			err := retry(context.Background(), testDevice, dic, testCase.write, func() error {
+				attempts++
+				if attempts <= len(testCase.failures) {
+					return testCase.failures[attempts-1]
+				}
+				return nil
+			})
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestValidateRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseDelay     string
+		maxDelay      string
+		expectedError bool
+	}{
+		{"valid - unset", "", "", false},
+		{"valid", "100ms", "2s", false},
+		{"invalid base delay", "100", "2s", true},
+		{"invalid max delay", "100ms", "2", true},
+		{"negative delay", "-100ms", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{Retry: config.RetryInfo{BaseDelay: tt.baseDelay, MaxDelay: tt.maxDelay}}}
+				},
+			})
+			err := ValidateRetry(dic)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type transactionKey struct{}
+
+// rollbackTimeout is the deadline of writing back each resource of a failed transaction when
+// Device.CommandTimeout is unset, so that a hung device never blocks the request.
+var rollbackTimeout = 10 * time.Second
+
+// Transaction collects the outcome of each resource operation of a transactional SET command.
+type Transaction struct {
+	Operations []dtos.ResourceOperationResult
+}
+
+// WithTransaction returns a copy of ctx in which SET commands are executed as a transaction,
+// and the Transaction which receives the outcome of each resource operation.
+func WithTransaction(ctx context.Context) (context.Context, *Transaction) {
+	tx := &Transaction{}
+	return context.WithValue(ctx, transactionKey{}, tx), tx
+}
+
+// write executes the protocol-specific write operation, as a transaction if requested.
+func (c *CommandProcessor) write(reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue) error {
+	if tx, ok := c.ctx.Value(transactionKey{}).(*Transaction); ok {
+		return c.writeTransaction(tx, reqs, params)
+	}
+	return writeCommands(c.ctx, c.device, reqs, params, c.dic)
+}
+
+// writeTransaction snapshots the current values of the resources, then writes the resources
+// one by one. On the first failure the remaining resources are skipped and the snapshot is
+// written back, in reverse order, to the failed resource and the ones written before it.
+// Write-only resources cannot be snapshotted and thus cannot be rolled back.
+func (c *CommandProcessor) writeTransaction(tx *Transaction, reqs []sdkModels.CommandRequest, params []*sdkModels.CommandValue) error {
+	tx.Operations = make([]dtos.ResourceOperationResult, len(reqs))
+	for i, req := range reqs {
+		tx.Operations[i] = dtos.ResourceOperationResult{DeviceResource: req.DeviceResourceName, Status: dtos.OperationSkipped}
+	}
+
+	var snapshotReqs []sdkModels.CommandRequest
+	for _, req := range reqs {
+		dr, ok := cache.Profiles().DeviceResource(c.device.ProfileName, req.DeviceResourceName)
+		if ok && dr.Properties.ReadWrite != common.ReadWrite_W {
+			snapshotReqs = append(snapshotReqs, req)
+		}
+	}
+	snapshot := make(map[string]*sdkModels.CommandValue, len(snapshotReqs))
+	if len(snapshotReqs) > 0 {
+		values, err := readCommands(c.ctx, c.device, snapshotReqs, c.dic)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot the resources before writing: %w", err)
+		}
+		if len(values) != len(snapshotReqs) {
+			return fmt.Errorf("expected %d CommandValues snapshotting the resources before writing, got %d", len(snapshotReqs), len(values))
+		}
+		for i, cv := range values {
+			if cv != nil {
+				snapshot[snapshotReqs[i].DeviceResourceName] = cv
+			}
+		}
+	}
+
+	failed := -1
+	var writeErr error
+	for i := range reqs {
+		writeErr = writeCommands(c.ctx, c.device, reqs[i:i+1], params[i:i+1], c.dic)
+		if writeErr != nil {
+			tx.Operations[i].Status = dtos.OperationFailed
+			tx.Operations[i].Message = writeErr.Error()
+			failed = i
+			break
+		}
+		tx.Operations[i].Status = dtos.OperationSucceeded
+	}
+	if failed < 0 {
+		return nil
+	}
+
+	for i := failed; i >= 0; i-- {
+		var rollbackErr error
+		previous, ok := snapshot[reqs[i].DeviceResourceName]
+		if ok {
+			ctx, cancel := c.rollbackContext()
+			rollbackErr = writeCommands(ctx, c.device, reqs[i:i+1], []*sdkModels.CommandValue{previous}, c.dic)
+			cancel()
+		} else {
+			rollbackErr = fmt.Errorf("no snapshot of resource %s", reqs[i].DeviceResourceName)
+		}
+
+		switch {
+		case i == failed && rollbackErr != nil:
+			tx.Operations[i].Message = fmt.Sprintf("%s; rollback failed: %v", tx.Operations[i].Message, rollbackErr)
+		case i == failed:
+			tx.Operations[i].Message = fmt.Sprintf("%s; rolled back", tx.Operations[i].Message)
+		case rollbackErr != nil:
+			tx.Operations[i].Status = dtos.OperationRollbackFailed
+			tx.Operations[i].Message = rollbackErr.Error()
+		default:
+			tx.Operations[i].Status = dtos.OperationRolledBack
+		}
+	}
+	readCache.invalidate(c.device.Name)
+
+	return writeErr
+}
+
+// rollbackContext returns the context of writing back a resource, which is not derived from
+// the command context as it may be already expired, e.g. if the failure was a timeout. It
+// is bound to Device.CommandTimeout, or to rollbackTimeout if unset.
+func (c *CommandProcessor) rollbackContext() (context.Context, context.CancelFunc) {
+	// the timeout is validated at startup
+	timeout, _ := commandTimeout(container.ConfigurationFrom(c.dic.Get).Device.CommandTimeout)
+	if timeout == 0 {
+		timeout = rollbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancelCommand := commandContext(ctx, c.correlationID, c.dic)
+	return ctx, func() {
+		cancelCommand()
+		cancel()
+	}
+}
@@ -0,0 +1,135 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestCommandProcessor_WriteTransaction(t *testing.T) {
+	rw := sdkModels.CommandRequest{DeviceResourceName: "test-resource", Type: common.ValueTypeString}
+	wo := sdkModels.CommandRequest{DeviceResourceName: "wo-resource", Type: common.ValueTypeString}
+	object := sdkModels.CommandRequest{DeviceResourceName: "rw-object", Type: common.ValueTypeObject}
+	oldRW, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "old")
+	newRW, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "new")
+	newWO, _ := sdkModels.NewCommandValue("wo-resource", common.ValueTypeString, "new")
+	oldObject, _ := sdkModels.NewCommandValue("rw-object", common.ValueTypeObject, map[string]interface{}{"foo": "old"})
+	newObject, _ := sdkModels.NewCommandValue("rw-object", common.ValueTypeObject, map[string]interface{}{"foo": "new"})
+	reqs := []sdkModels.CommandRequest{rw, wo, object}
+	params := []*sdkModels.CommandValue{newRW, newWO, newObject}
+	writeErr := stdErrors.New("write failed")
+
+	tests := []struct {
+		name             string
+		snapshotErr      error
+		failingResource  string
+		expectedErr      bool
+		expectedStatuses []string
+	}{
+		{"all written", nil, "", false,
+			[]string{dtos.OperationSucceeded, dtos.OperationSucceeded, dtos.OperationSucceeded}},
+		{"rolled back", nil, "rw-object", true,
+			[]string{dtos.OperationRolledBack, dtos.OperationRollbackFailed, dtos.OperationFailed}},
+		{"write-only resource not restorable", nil, "wo-resource", true,
+			[]string{dtos.OperationRolledBack, dtos.OperationFailed, dtos.OperationSkipped}},
+		{"snapshot failed", writeErr, "", true,
+			[]string{dtos.OperationSkipped, dtos.OperationSkipped, dtos.OperationSkipped}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver := &mocks.ProtocolDriver{}
+			driver.On("HandleReadCommands", "test-device", testProtocols, []sdkModels.CommandRequest{rw, object}).
+				Return([]*sdkModels.CommandValue{oldRW, oldObject}, testCase.snapshotErr)
+			for i, req := range reqs {
+				var err error
+				if req.DeviceResourceName == testCase.failingResource {
+					err = writeErr
+				}
+				driver.On("HandleWriteCommands", "test-device", testProtocols, reqs[i:i+1], params[i:i+1]).Return(err)
+			}
+			driver.On("HandleWriteCommands", "test-device", testProtocols, []sdkModels.CommandRequest{rw}, []*sdkModels.CommandValue{oldRW}).Return(nil)
+			driver.On("HandleWriteCommands", "test-device", testProtocols, []sdkModels.CommandRequest{object}, []*sdkModels.CommandValue{oldObject}).Return(nil)
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ProtocolDriverName: func(get di.Get) interface{} {
+					return driver
+				},
+			})
+			edgexErr := cache.InitCache("test-service", dic)
+			require.NoError(t, edgexErr)
+
+			ctx, tx := WithTransaction(context.Background())
+			err := NewCommandProcessor(ctx, testDevice, "test-command", uuid.NewString(), nil, "", dic).write(reqs, params)
+			if testCase.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, tx.Operations, len(reqs))
+			for i, op := range tx.Operations {
+				assert.Equal(t, reqs[i].DeviceResourceName, op.DeviceResource)
+				assert.Equal(t, testCase.expectedStatuses[i], op.Status, op.DeviceResource)
+			}
+		})
+	}
+}
+
+func TestCommandProcessor_WriteTransaction_RollbackDeadline(t *testing.T) {
+	rw := sdkModels.CommandRequest{DeviceResourceName: "test-resource", Type: common.ValueTypeString}
+	object := sdkModels.CommandRequest{DeviceResourceName: "rw-object", Type: common.ValueTypeObject}
+	oldRW, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "old")
+	newRW, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "new")
+	oldObject, _ := sdkModels.NewCommandValue("rw-object", common.ValueTypeObject, map[string]interface{}{"foo": "old"})
+	newObject, _ := sdkModels.NewCommandValue("rw-object", common.ValueTypeObject, map[string]interface{}{"foo": "new"})
+	reqs := []sdkModels.CommandRequest{rw, object}
+	params := []*sdkModels.CommandValue{newRW, newObject}
+
+	// the device hangs after the first write, and the rollback is abandoned
+	driver := &mocks.ProtocolDriver{}
+	driver.On("HandleReadCommands", "test-device", testProtocols, reqs).Return([]*sdkModels.CommandValue{oldRW, oldObject}, nil)
+	driver.On("HandleWriteCommands", "test-device", testProtocols, reqs[:1], params[:1]).Return(nil)
+	driver.On("HandleWriteCommands", "test-device", testProtocols, reqs[1:], params[1:]).Return(stdErrors.New("write failed"))
+	driver.On("HandleWriteCommands", "test-device", testProtocols, mock.Anything, mock.Anything).After(time.Second).Return(nil)
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+	edgexErr := cache.InitCache("test-service", dic)
+	require.NoError(t, edgexErr)
+
+	defer func(timeout time.Duration) {
+		rollbackTimeout = timeout
+	}(rollbackTimeout)
+	rollbackTimeout = 10 * time.Millisecond
+
+	ctx, tx := WithTransaction(context.Background())
+	start := time.Now()
+	err := NewCommandProcessor(ctx, testDevice, "test-command", uuid.NewString(), nil, "", dic).write(reqs, params)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	require.Len(t, tx.Operations, len(reqs))
+	assert.Equal(t, dtos.OperationRollbackFailed, tx.Operations[0].Status)
+	assert.Equal(t, dtos.OperationFailed, tx.Operations[1].Status)
+}
@@ -0,0 +1,201 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// VerifyMismatch is the value of the ds-verify tag of the event published when the
+// values read back after a SET command differ from the requested ones.
+const VerifyMismatch = "mismatch"
+
+type verifyKey struct{}
+
+// WithVerify returns a copy of ctx in which SET commands read back all the written device
+// resources and compare them with the requested values if verify is true, or none of them
+// if false, overriding the ds-verify settings of the device command and device resources.
+func WithVerify(ctx context.Context, verify bool) context.Context {
+	return context.WithValue(ctx, verifyKey{}, verify)
+}
+
+// parseVerify parses a ds-verify value, which is yes or no, or any boolean accepted by
+// strconv.ParseBool.
+func parseVerify(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case common.ValueYes:
+		return true, nil
+	case common.ValueNo:
+		return false, nil
+	default:
+		return strconv.ParseBool(s)
+	}
+}
+
+// ValidateVerifyDelay checks Device.Verify.Delay.
+func ValidateVerifyDelay(dic *di.Container) errors.EdgeX {
+	_, err := verifyDelay(container.ConfigurationFrom(dic.Get).Device.Verify.Delay)
+	return err
+}
+
+func verifyDelay(s string) (time.Duration, errors.EdgeX) {
+	if s == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(s)
+	if err != nil || delay < 0 {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.Verify.Delay %s", s), err)
+	}
+	return delay, nil
+}
+
+// verifySetting resolves whether the written resource is verified by the SET command. The
+// ds-verify query parameter takes precedence over the ds-verify:<command> protocol property
+// of the device, which takes precedence over the ds-verify attribute of the device resource.
+func (c *CommandProcessor) verifySetting(req sdkModels.CommandRequest) bool {
+	if verify, ok := c.ctx.Value(verifyKey{}).(bool); ok {
+		return verify
+	}
+	if v, ok := sdkCommon.ProtocolProperty(c.device, fmt.Sprintf("%s:%s", sdkCommon.Verify, c.sourceName)); ok {
+		verify, _ := parseVerify(v)
+		return verify
+	}
+	verify, _ := parseVerify(fmt.Sprint(req.Attributes[sdkCommon.Verify]))
+	return verify
+}
+
+// verifyWrite reads back the written resources subject to verification, applies the read
+// transformations and compares the results with the requested values, i.e. the values of
+// the SET command before the write transformations.
+func (c *CommandProcessor) verifyWrite(reqs []sdkModels.CommandRequest, requested []*sdkModels.CommandValue) errors.EdgeX {
+	configuration := container.ConfigurationFrom(c.dic.Get)
+
+	var verifyReqs []sdkModels.CommandRequest
+	var expected []*sdkModels.CommandValue
+	for i, req := range reqs {
+		dr, ok := cache.Profiles().DeviceResource(c.device.ProfileName, req.DeviceResourceName)
+		if !c.verifySetting(req) || !ok || dr.Properties.ReadWrite == common.ReadWrite_W {
+			continue
+		}
+		verifyReqs = append(verifyReqs, req)
+		expected = append(expected, requested[i])
+	}
+	if len(verifyReqs) == 0 {
+		return nil
+	}
+
+	// the delay is validated at startup
+	if delay, _ := verifyDelay(configuration.Device.Verify.Delay); delay > 0 {
+		select {
+		case <-c.ctx.Done():
+		case <-time.After(delay):
+		}
+	}
+
+	results, err := readCommands(c.ctx, c.device, verifyReqs, c.dic)
+	if err != nil {
+		errMsg := fmt.Sprintf("error reading back %s for %s", c.sourceName, c.device.Name)
+		return driverError(c.ctx, errMsg, err)
+	}
+	if len(results) != len(verifyReqs) {
+		errMsg := fmt.Sprintf("expected %d CommandValues reading back %s for %s, got %d", len(verifyReqs), c.sourceName, c.device.Name, len(results))
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+
+	var mismatches []string
+	for i, result := range results {
+		dr, _ := cache.Profiles().DeviceResource(c.device.ProfileName, verifyReqs[i].DeviceResourceName)
+		actual := copyCommandValue(result)
+		if actual == nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: no value read", dr.Name))
+			continue
+		}
+		if configuration.Device.DataTransform {
+			edgexErr := transformer.TransformReadResult(actual, dr.Properties)
+			if edgexErr != nil {
+				return errors.NewCommonEdgeX(errors.KindServerError, "failed to transform read back value", edgexErr)
+			}
+		}
+
+		tolerance := configuration.Device.Verify.Tolerance
+		if v, ok := dr.Attributes[sdkCommon.VerifyTolerance]; ok {
+			if t, err := strconv.ParseFloat(fmt.Sprint(v), 64); err == nil {
+				tolerance = t
+			}
+		}
+		if !valuesMatch(expected[i].Value, actual.Value, tolerance) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: requested %v, read %v", dr.Name, expected[i].Value, actual.Value))
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	if configuration.Device.Verify.PublishEvent {
+		event, edgexErr := transformer.CommandValuesToEventDTO(results, c.device.Name, c.sourceName, c.dic)
+		if edgexErr == nil && event != nil {
+			event.Tags[sdkCommon.Verify] = VerifyMismatch
+			go sdkCommon.SendEvent(event, c.correlationID, c.dic)
+		}
+	}
+
+	errMsg := fmt.Sprintf("verification of %s for %s failed: %s", c.sourceName, c.device.Name, strings.Join(mismatches, "; "))
+	return errors.NewCommonEdgeX(errors.KindStatusConflict, errMsg, nil)
+}
+
+// valuesMatch compares numeric values within tolerance and any other values for equality.
+func valuesMatch(expected interface{}, actual interface{}, tolerance float64) bool {
+	e, eOk := numericValue(expected)
+	a, aOk := numericValue(actual)
+	if eOk && aOk {
+		return math.Abs(e-a) <= tolerance
+	}
+	return reflect.DeepEqual(expected, actual) || fmt.Sprint(expected) == fmt.Sprint(actual)
+}
+
+func numericValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
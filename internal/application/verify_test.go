@@ -0,0 +1,167 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestValuesMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		expected  interface{}
+		actual    interface{}
+		tolerance float64
+		match     bool
+	}{
+		{"equal integers", int16(5), int16(5), 0, true},
+		{"different integers", int16(5), int16(6), 0, false},
+		{"integers within tolerance", int16(5), int16(6), 1, true},
+		{"floats within tolerance", float32(1.5), float32(1.52), 0.05, true},
+		{"floats out of tolerance", float64(1.5), float64(1.6), 0.05, false},
+		{"equal strings", "on", "on", 0, true},
+		{"different strings", "on", "off", 0, false},
+		{"equal arrays", []int8{1, 2}, []int8{1, 2}, 0, true},
+		{"different arrays", []int8{1, 2}, []int8{1, 3}, 0, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.match, valuesMatch(testCase.expected, testCase.actual, testCase.tolerance))
+		})
+	}
+}
+
+func TestCommandProcessor_WriteDeviceResource_Verify(t *testing.T) {
+	cr := sdkModels.CommandRequest{
+		DeviceResourceName: "test-resource",
+		Attributes:         nil,
+		Type:               common.ValueTypeString,
+	}
+	cv, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "test-value")
+	cv.Tags = make(map[string]string)
+	unchanged, _ := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "old-value")
+
+	verifiedCommand := testDevice
+	verifiedCommand.Protocols = map[string]models.ProtocolProperties{"other": {sdkCommon.Verify + ":test-resource": "yes"}}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		device        models.Device
+		readBack      *sdkModels.CommandValue
+		expectedRead  bool
+		errorExpected bool
+	}{
+		{"no verification", context.Background(), testDevice, nil, false, false},
+		{"verification disabled", WithVerify(context.Background(), false), testDevice, nil, false, false},
+		{"value applied", WithVerify(context.Background(), true), testDevice, cv, true, false},
+		{"value not applied", WithVerify(context.Background(), true), testDevice, unchanged, true, true},
+		{"command verified", context.Background(), verifiedCommand, unchanged, true, true},
+		{"command verification disabled", WithVerify(context.Background(), false), verifiedCommand, nil, false, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver := &mocks.ProtocolDriver{}
+			driver.On("HandleWriteCommands", "test-device", mock.Anything, []sdkModels.CommandRequest{cr}, []*sdkModels.CommandValue{cv}).Return(nil)
+			driver.On("HandleReadCommands", "test-device", mock.Anything, []sdkModels.CommandRequest{cr}).Return([]*sdkModels.CommandValue{testCase.readBack}, nil)
+			dic := mockDic()
+			dic.Update(di.ServiceConstructorMap{
+				container.ProtocolDriverName: func(get di.Get) interface{} {
+					return driver
+				},
+			})
+			err := cache.InitCache("test-service", dic)
+			require.NoError(t, err)
+
+			params := map[string]interface{}{"test-resource": "test-value"}
+			edgexErr := NewCommandProcessor(testCase.ctx, testCase.device, "test-resource", uuid.NewString(), params, "", dic).WriteDeviceResource()
+			if testCase.errorExpected {
+				require.Error(t, edgexErr)
+				assert.Equal(t, http.StatusConflict, edgexErr.Code())
+			} else {
+				require.NoError(t, edgexErr)
+			}
+			if testCase.expectedRead {
+				driver.AssertCalled(t, "HandleReadCommands", "test-device", mock.Anything, []sdkModels.CommandRequest{cr})
+			} else {
+				driver.AssertNotCalled(t, "HandleReadCommands", "test-device", mock.Anything, []sdkModels.CommandRequest{cr})
+			}
+		})
+	}
+}
+
+func TestParseVerify(t *testing.T) {
+	tests := []struct {
+		value         string
+		expected      bool
+		expectedError bool
+	}{
+		{"yes", true, false},
+		{"no", false, false},
+		{"true", true, false},
+		{"FALSE", false, false},
+		{"1", true, false},
+		{"maybe", false, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.value, func(t *testing.T) {
+			verify, err := parseVerify(testCase.value)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, verify)
+		})
+	}
+}
+
+func TestValidateVerifyDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		delay         string
+		expectedError bool
+	}{
+		{"valid - unset", "", false},
+		{"valid", "100ms", false},
+		{"invalid - unit", "100", true},
+		{"invalid - negative", "-100ms", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{Verify: config.VerifyInfo{Delay: tt.delay}}}
+				},
+			})
+			err := ValidateVerifyDelay(dic)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
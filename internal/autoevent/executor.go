@@ -23,70 +23,120 @@ import (
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 )
 
 type Executor struct {
 	deviceName   string
 	sourceName   string
+	interval     string
 	onChange     bool
 	lastReadings map[string]interface{}
-	duration     time.Duration
-	stop         bool
+	schedule     schedule
+	ctx          context.Context
+	cancel       context.CancelFunc
 	mutex        *sync.Mutex
+
+	// the scheduling state and statistics, guarded by the mutex of the scheduler
+	next         time.Time
+	index        int
+	running      bool
+	missed       int
+	removed      bool
+	paused       bool
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastErr      error
+	runs         int64
+	failures     int64
+	sent         int64
+	suppressed   int64
+	missedTicks  int64
+}
+
+// runResult is the outcome of a reading of an Executor.
+type runResult struct {
+	// sent indicates an event was sent
+	sent bool
+	// changed indicates the readings changed since the previous reading
+	changed bool
+	err     error
 }
 
-// Run triggers this Executor executes the handler for the event source periodically
-func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, buffer chan bool, dic *di.Container) {
-	wg.Add(1)
-	defer wg.Done()
+// fire reads the event source once and sends the resulting event
+func (e *Executor) fire(buffer chan bool, dic *di.Container) runResult {
+	if e.ctx.Err() != nil {
+		return runResult{}
+	}
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
-	for {
+	lc.Debugf("AutoEvent - reading %s", e.sourceName)
+	evt, err := readResource(e.ctx, e, dic)
+	if err != nil {
+		lc.Errorf("AutoEvent - error occurs when reading resource %s: %v", e.sourceName, err)
+		return runResult{err: err}
+	}
+
+	evt = transformer.FilterEvent(evt)
+	if evt == nil {
+		lc.Debugf("AutoEvent - no event generated when reading resource %s, or readings within deadband", e.sourceName)
+		return runResult{}
+	}
+	changed := true
+	if _, adaptive := e.schedule.(*adaptiveSchedule); e.onChange || adaptive {
+		changed = !e.compareReadings(evt.Readings)
+	}
+	if e.onChange && !changed {
+		lc.Debugf("AutoEvent - readings are the same as previous one")
+		return runResult{}
+	}
+	// After the auto event executes a read command, it will create a goroutine to send out events.
+	// When the concurrent auto event amount becomes large, core-data might be hard to handle so many HTTP requests at the same time.
+	// The device service will get some network errors like EOF or Connection reset by peer.
+	// By adding a buffer here, the user can use the Service.AsyncBufferSize configuration to control the goroutine for sending events.
+	go func() {
 		select {
-		case <-ctx.Done():
+		case buffer <- true:
+		case <-e.ctx.Done():
+			lc.Debugf("AutoEvent - %s stopped before its event was sent", e.sourceName)
 			return
-		case <-time.After(e.duration):
-			if e.stop {
-				return
-			}
-			lc.Debugf("AutoEvent - reading %s", e.sourceName)
-			evt, err := readResource(e, dic)
-			if err != nil {
-				lc.Errorf("AutoEvent - error occurs when reading resource %s: %v", e.sourceName, err)
-				continue
-			}
-
-			if evt != nil {
-				if e.onChange {
-					if e.compareReadings(evt.Readings) {
-						lc.Debugf("AutoEvent - readings are the same as previous one")
-						continue
-					}
-				}
-				// After the auto event executes a read command, it will create a goroutine to send out events.
-				// When the concurrent auto event amount becomes large, core-data might be hard to handle so many HTTP requests at the same time.
-				// The device service will get some network errors like EOF or Connection reset by peer.
-				// By adding a buffer here, the user can use the Service.AsyncBufferSize configuration to control the goroutine for sending events.
-				go func() {
-					buffer <- true
-					correlationId := uuid.NewString()
-					sdkCommon.SendEvent(evt, correlationId, dic)
-					lc.Tracef("AutoEvent - Sent new Event/Reading for '%s' source with Correlation Id '%s'", evt.SourceName, correlationId)
-					<-buffer
-				}()
-			} else {
-				lc.Debugf("AutoEvent - no event generated when reading resource %s", e.sourceName)
-			}
 		}
+		correlationId := uuid.NewString()
+		sdkCommon.SendEvent(evt, correlationId, dic)
+		lc.Tracef("AutoEvent - Sent new Event/Reading for '%s' source with Correlation Id '%s'", evt.SourceName, correlationId)
+		<-buffer
+	}()
+	return runResult{sent: true, changed: changed}
+}
+
+// makeAdaptive adapts the interval of the Executor between min and max to the readings,
+// see adaptiveSchedule, a bound of 0 meaning the interval of the AutoEvent. The schedule of
+// the Executor must be an interval.
+func (e *Executor) makeAdaptive(min time.Duration, max time.Duration) error {
+	interval, ok := e.schedule.(intervalSchedule)
+	if !ok {
+		return fmt.Errorf("the interval of AutoEvent %s is a schedule expression", e.sourceName)
+	}
+	if min == 0 {
+		min = interval.interval
+	}
+	if max == 0 {
+		max = interval.interval
 	}
+	if min < 0 || min > max {
+		return fmt.Errorf("invalid adaptive interval bounds %s-%s", min, max)
+	}
+	e.schedule = newAdaptiveSchedule(interval.interval, min, max)
+	return nil
 }
 
-func readResource(e *Executor, dic *di.Container) (event *dtos.Event, err errors.EdgeX) {
+func readResource(ctx context.Context, e *Executor, dic *di.Container) (event *dtos.Event, err errors.EdgeX) {
 	vars := make(map[string]string, 2)
 	vars[common.Name] = e.deviceName
 	vars[common.Command] = e.sourceName
 
-	res, err := application.CommandHandler(true, false, "", vars, nil, "", dic)
+	// the AutoEvents always read the device rather than republishing a cached value
+	res, err := application.CommandHandler(application.WithMaxAge(ctx, 0), true, false, "", vars, nil, "", dic)
 	if err != nil {
 		return event, err
 	}
@@ -137,24 +187,32 @@ func (e *Executor) renewLastReadings(readings []dtos.BaseReading) {
 	}
 }
 
-// Stop marks this Executor stopped
+// Stop cancels the context of this Executor, aborting its reading in progress, if any, and
+// the sending of its pending event
 func (e *Executor) Stop() {
-	e.stop = true
+	e.cancel()
 }
 
-// NewExecutor creates an Executor for an AutoEvent
-func NewExecutor(deviceName string, ae models.AutoEvent) (*Executor, errors.EdgeX) {
+// NewExecutor creates an Executor for an AutoEvent, which is stopped once ctx is done
+func NewExecutor(ctx context.Context, deviceName string, ae models.AutoEvent) (*Executor, errors.EdgeX) {
 	// check Frequency
-	duration, err := time.ParseDuration(ae.Interval)
+	schedule, err := parseSchedule(ae.Interval)
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to parse AutoEvent %s duration", ae.SourceName), err)
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to parse AutoEvent %s schedule", ae.SourceName), err)
+	}
+	if schedule.next(time.Now()).IsZero() {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("AutoEvent %s schedule %s never fires", ae.SourceName, ae.Interval), nil)
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
 	return &Executor{
 		deviceName: deviceName,
 		sourceName: ae.SourceName,
+		interval:   ae.Interval,
 		onChange:   ae.OnChange,
-		duration:   duration,
-		stop:       false,
-		mutex:      &sync.Mutex{}}, nil
+		schedule:   schedule,
+		ctx:        ctx,
+		cancel:     cancel,
+		mutex:      &sync.Mutex{},
+		index:      -1}, nil
 }
@@ -7,6 +7,7 @@
 package autoevent
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 
@@ -19,7 +20,7 @@ import (
 
 func TestCompareReadings(t *testing.T) {
 	autoEvent := models.AutoEvent{SourceName: "sourceName", OnChange: true, Interval: "500ms"}
-	e, err := NewExecutor("device-test", autoEvent)
+	e, err := NewExecutor(context.Background(), "device-test", autoEvent)
 	require.NoError(t, err)
 
 	testReadings := []dtos.BaseReading{{ResourceName: "r1"}, {ResourceName: "r2"}}
@@ -8,15 +8,22 @@ package autoevent
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
 )
 
 type manager struct {
@@ -25,7 +32,11 @@ type manager struct {
 	wg              *sync.WaitGroup
 	mutex           sync.Mutex
 	autoeventBuffer chan bool
+	scheduler       *scheduler
 	dic             *di.Container
+	// paused holds the device sources whose AutoEvents are paused, see pausedKey, so that
+	// they stay paused when the AutoEvents of the device are restarted
+	paused map[string]bool
 }
 
 func BootstrapHandler(
@@ -33,14 +44,25 @@ func BootstrapHandler(
 	wg *sync.WaitGroup,
 	_ startup.Timer,
 	dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	config := container.ConfigurationFrom(dic.Get)
 	m := &manager{
 		ctx:             ctx,
 		wg:              wg,
 		executorMap:     make(map[string][]*Executor),
+		paused:          make(map[string]bool),
 		dic:             dic,
 		autoeventBuffer: make(chan bool, config.Device.AsyncBufferSize),
 	}
+	s, err := newScheduler(config.Device.AutoEventScheduling, func(e *Executor) runResult {
+		return e.fire(m.autoeventBuffer, dic)
+	})
+	if err != nil {
+		lc.Errorf("failed to create the AutoEvent scheduler: %v", err)
+		return false
+	}
+	m.scheduler = s
+	s.start(ctx, wg)
 
 	dic.Update(di.ServiceConstructorMap{
 		container.ManagerName: func(get di.Get) interface{} {
@@ -57,53 +79,205 @@ func (m *manager) StartAutoEvents() {
 
 	for _, d := range cache.Devices().All() {
 		if _, ok := m.executorMap[d.Name]; !ok {
-			executors := m.triggerExecutors(d.Name, d.AutoEvents, m.dic)
+			executors := m.triggerExecutors(d, m.dic)
 			m.executorMap[d.Name] = executors
 		}
 	}
 }
 
-func (m *manager) triggerExecutors(deviceName string, autoEvents []models.AutoEvent, dic *di.Container) []*Executor {
+func (m *manager) triggerExecutors(device models.Device, dic *di.Container) []*Executor {
 	var executors []*Executor
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
-	for _, autoEvent := range autoEvents {
-		executor, err := NewExecutor(deviceName, autoEvent)
+	jitter := m.scheduler.jitter
+	if v, ok := sdkCommon.ProtocolProperty(device, sdkCommon.StartupJitter); ok {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			jitter = d
+		}
+	}
+	for _, autoEvent := range device.AutoEvents {
+		if schedule, ok := sdkCommon.ProtocolProperty(device, fmt.Sprintf("%s:%s", sdkCommon.Schedule, autoEvent.SourceName)); ok {
+			autoEvent.Interval = schedule
+		}
+		executor, err := NewExecutor(m.ctx, device.Name, autoEvent)
 		if err != nil {
-			lc.Errorf("failed to create executor of AutoEvent %s for Device %s: %v", autoEvent.SourceName, deviceName, err)
+			lc.Errorf("failed to create executor of AutoEvent %s for Device %s: %v", autoEvent.SourceName, device.Name, err)
 			// skip this AutoEvent if it causes error during creation
 			continue
 		}
+		if min, max, ok := adaptiveBounds(device, autoEvent.SourceName); ok {
+			if err := executor.makeAdaptive(min, max); err != nil {
+				lc.Warnf("AutoEvent %s of Device %s keeps a fixed interval: %v", autoEvent.SourceName, device.Name, err)
+			}
+		}
+		// the executor is not shared yet
+		executor.paused = m.paused[pausedKey(device.Name, autoEvent.SourceName)]
 		executors = append(executors, executor)
-		go executor.Run(m.ctx, m.wg, m.autoeventBuffer, dic)
+		m.scheduler.add(executor, jitter)
 	}
 	return executors
 }
 
+// adaptiveBounds returns the ds-mininterval and ds-maxinterval of the AutoEvent of the device
+// source, 0 for the unset or invalid ones, and false if neither is set.
+func adaptiveBounds(device models.Device, sourceName string) (time.Duration, time.Duration, bool) {
+	var bounds [2]time.Duration
+	var found bool
+	for i, name := range []string{sdkCommon.MinInterval, sdkCommon.MaxInterval} {
+		v, ok := sdkCommon.ProtocolProperty(device, fmt.Sprintf("%s:%s", name, sourceName))
+		if !ok {
+			v, ok = sdkCommon.ProtocolProperty(device, name)
+		}
+		if !ok {
+			continue
+		}
+		found = true
+		if d, err := time.ParseDuration(v); err == nil {
+			bounds[i] = d
+		}
+	}
+	return bounds[0], bounds[1], found
+}
+
 func (m *manager) RestartForDevice(deviceName string) {
 	lc := bootstrapContainer.LoggingClientFrom(m.dic.Get)
 
-	m.StopForDevice(deviceName)
+	// the executors are stopped and started under the same lock so that concurrent restarts
+	// of the device don't leave orphan executors in the scheduler
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stopExecutors(deviceName)
 	d, ok := cache.Devices().ForName(deviceName)
 	if !ok {
 		lc.Errorf("failed to find device %s in cache to start AutoEvent", deviceName)
+		m.forgetPaused(deviceName)
+		return
 	}
+	m.executorMap[deviceName] = m.triggerExecutors(d, m.dic)
+}
 
+func (m *manager) StopForDevice(deviceName string) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	executors := m.triggerExecutors(deviceName, d.AutoEvents, m.dic)
-	m.executorMap[deviceName] = executors
+	m.stopExecutors(deviceName)
 }
 
-func (m *manager) StopForDevice(deviceName string) {
+// stopExecutors stops and unschedules the executors of the device. The mutex must be held.
+func (m *manager) stopExecutors(deviceName string) {
+	for _, executor := range m.executorMap[deviceName] {
+		executor.Stop()
+		m.scheduler.remove(executor)
+	}
+	delete(m.executorMap, deviceName)
+}
+
+func (m *manager) AutoEventStatuses(deviceName string) ([]dtos.AutoEventStatus, errors.EdgeX) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	executors, ok := m.executorMap[deviceName]
-	if ok {
-		for _, executor := range executors {
-			executor.Stop()
+	var deviceNames []string
+	if deviceName == "" {
+		for name := range m.executorMap {
+			deviceNames = append(deviceNames, name)
+		}
+		sort.Strings(deviceNames)
+	} else {
+		if _, ok := cache.Devices().ForName(deviceName); !ok {
+			return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("failed to find device %s", deviceName), nil)
+		}
+		deviceNames = []string{deviceName}
+	}
+
+	statuses := make([]dtos.AutoEventStatus, 0)
+	for _, name := range deviceNames {
+		for _, executor := range m.executorMap[name] {
+			statuses = append(statuses, m.scheduler.status(executor))
 		}
-		delete(m.executorMap, deviceName)
 	}
+	return statuses, nil
+}
+
+func (m *manager) PauseAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.executorsFor(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	m.paused[pausedKey(deviceName, sourceName)] = true
+	for _, executor := range executors {
+		m.scheduler.pause(executor)
+	}
+	return nil
+}
+
+func (m *manager) ResumeAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.executorsFor(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	delete(m.paused, pausedKey(deviceName, sourceName))
+	for _, executor := range executors {
+		m.scheduler.resume(executor)
+	}
+	return nil
+}
+
+func (m *manager) TriggerAutoEvent(deviceName string, sourceName string) errors.EdgeX {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	executors, err := m.executorsFor(deviceName, sourceName)
+	if err != nil {
+		return err
+	}
+	var triggered bool
+	for _, executor := range executors {
+		if m.scheduler.trigger(executor) {
+			triggered = true
+		}
+	}
+	if !triggered {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("AutoEvent %s of device %s is already reading", sourceName, deviceName), nil)
+	}
+	return nil
+}
+
+func (m *manager) ForgetDevice(deviceName string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.forgetPaused(deviceName)
+}
+
+// executorsFor returns the executors of the AutoEvents of the device source. The mutex must
+// be held.
+func (m *manager) executorsFor(deviceName string, sourceName string) ([]*Executor, errors.EdgeX) {
+	var executors []*Executor
+	for _, executor := range m.executorMap[deviceName] {
+		if executor.sourceName == sourceName {
+			executors = append(executors, executor)
+		}
+	}
+	if len(executors) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("failed to find AutoEvent %s of device %s", sourceName, deviceName), nil)
+	}
+	return executors, nil
+}
+
+// forgetPaused forgets the paused AutoEvents of the device. The mutex must be held.
+func (m *manager) forgetPaused(deviceName string) {
+	prefix := pausedKey(deviceName, "")
+	for key := range m.paused {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.paused, key)
+		}
+	}
+}
+
+func pausedKey(deviceName string, sourceName string) string {
+	return deviceName + "/" + sourceName
 }
@@ -0,0 +1,329 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+const (
+	testServiceName = "test-service"
+	testDeviceName  = "test-device"
+	testProfileName = "test-profile"
+)
+
+func mockDic() *di.Container {
+	device := models.Device{
+		Name:           testDeviceName,
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		ServiceName:    testServiceName,
+		ProfileName:    testProfileName,
+		AutoEvents: []models.AutoEvent{
+			{SourceName: "test-resource", Interval: "1h"},
+			{SourceName: "other-resource", Interval: "@aligned 1h"},
+		},
+	}
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), testServiceName, 0, -1).
+		Return(responses.MultiDevicesResponse{Devices: []dtos.Device{dtos.FromDeviceModelToDTO(device)}}, nil)
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), testProfileName).
+		Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{Name: testProfileName}}, nil)
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", context.Background(), testServiceName, 0, -1).
+		Return(responses.MultiProvisionWatchersResponse{}, nil)
+
+	configuration := &config.ConfigurationStruct{
+		Device: config.DeviceInfo{AsyncBufferSize: 1},
+	}
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &models.DeviceService{Name: testServiceName, AdminState: models.Unlocked}
+		},
+	})
+}
+
+func newTestManager(t *testing.T) (*manager, func()) {
+	dic := mockDic()
+	require.NoError(t, cache.InitCache(testServiceName, dic))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.True(t, BootstrapHandler(ctx, wg, startup.Timer{}, dic))
+	m, ok := container.ManagerFrom(dic.Get).(*manager)
+	require.True(t, ok)
+	return m, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (m *manager) scheduled() int {
+	m.scheduler.mutex.Lock()
+	defer m.scheduler.mutex.Unlock()
+	return len(m.scheduler.executors)
+}
+
+func TestManager_StopForDevice(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+
+	m.RestartForDevice(testDeviceName)
+	m.mutex.Lock()
+	executors := m.executorMap[testDeviceName]
+	m.mutex.Unlock()
+	require.Len(t, executors, 2)
+	assert.Equal(t, 2, m.scheduled())
+
+	m.StopForDevice(testDeviceName)
+	for _, e := range executors {
+		// the context is cancelled by the time StopForDevice returns
+		assert.Error(t, e.ctx.Err(), "executor %s not stopped", e.sourceName)
+		assert.Equal(t, runResult{}, e.fire(m.autoeventBuffer, m.dic))
+	}
+	assert.Zero(t, m.scheduled())
+	statuses, err := m.AutoEventStatuses("")
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestManager_RestartForMissingDevice(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+
+	m.RestartForDevice("missing-device")
+	m.mutex.Lock()
+	_, ok := m.executorMap["missing-device"]
+	m.mutex.Unlock()
+	assert.False(t, ok, "executors started for a missing device")
+	assert.Zero(t, m.scheduled())
+
+	// the executors of a device removed from the cache are stopped on restart
+	m.RestartForDevice(testDeviceName)
+	require.Equal(t, 2, m.scheduled())
+	require.NoError(t, cache.Devices().RemoveByName(testDeviceName))
+	m.RestartForDevice(testDeviceName)
+	assert.Zero(t, m.scheduled())
+	statuses, err := m.AutoEventStatuses("")
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestManager_PauseAcrossRestart(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+
+	paused := func() map[string]bool {
+		statuses, err := m.AutoEventStatuses(testDeviceName)
+		require.NoError(t, err)
+		result := make(map[string]bool, len(statuses))
+		for _, status := range statuses {
+			result[status.SourceName] = status.Paused
+		}
+		return result
+	}
+
+	m.RestartForDevice(testDeviceName)
+	require.NoError(t, m.PauseAutoEvent(testDeviceName, "test-resource"))
+	m.RestartForDevice(testDeviceName)
+	assert.Equal(t, map[string]bool{"test-resource": true, "other-resource": false}, paused())
+	m.StopForDevice(testDeviceName)
+	m.RestartForDevice(testDeviceName)
+	assert.Equal(t, map[string]bool{"test-resource": true, "other-resource": false}, paused())
+
+	require.NoError(t, m.ResumeAutoEvent(testDeviceName, "test-resource"))
+	m.RestartForDevice(testDeviceName)
+	assert.Equal(t, map[string]bool{"test-resource": false, "other-resource": false}, paused())
+
+	// the pause of a device removed is forgotten
+	require.NoError(t, m.PauseAutoEvent(testDeviceName, "test-resource"))
+	require.NoError(t, cache.Devices().RemoveByName(testDeviceName))
+	m.RestartForDevice(testDeviceName)
+	m.mutex.Lock()
+	assert.Empty(t, m.paused)
+	m.mutex.Unlock()
+}
+
+func TestManager_PauseForgottenOnDelete(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+
+	driver := &mocks.ProtocolDriver{}
+	driver.On("RemoveDevice", testDeviceName, mock.Anything).Return(nil)
+	m.dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+	device, ok := cache.Devices().ForName(testDeviceName)
+	require.True(t, ok)
+	profile, ok := cache.Profiles().ForName(testProfileName)
+	require.True(t, ok)
+
+	m.RestartForDevice(testDeviceName)
+	require.NoError(t, m.PauseAutoEvent(testDeviceName, "test-resource"))
+	require.NoError(t, application.DeleteDevice(testDeviceName, m.dic))
+
+	// the device added again with the same name starts with its AutoEvents running
+	require.NoError(t, cache.Profiles().Add(profile))
+	require.NoError(t, cache.Devices().Add(device))
+	m.RestartForDevice(testDeviceName)
+	statuses, err := m.AutoEventStatuses(testDeviceName)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.False(t, status.Paused, "AutoEvent %s paused", status.SourceName)
+	}
+}
+
+func TestManager_NoGoroutineLeak(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+	baseline := runtime.NumGoroutine()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.RestartForDevice(testDeviceName)
+				// the readings in progress are left to complete by the executors stopped
+				_ = m.TriggerAutoEvent(testDeviceName, "test-resource")
+				if j%2 == 0 {
+					m.StopForDevice(testDeviceName)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the concurrent restarts leave the executors of a single restart scheduled
+	m.RestartForDevice(testDeviceName)
+	m.mutex.Lock()
+	executors := m.executorMap[testDeviceName]
+	m.mutex.Unlock()
+	assert.Len(t, executors, 2)
+	assert.Equal(t, len(executors), m.scheduled())
+
+	m.StopForDevice(testDeviceName)
+	assert.Zero(t, m.scheduled())
+	// polled from the test goroutine, as assert.Eventually runs the condition in a goroutine
+	goroutines := runtime.NumGoroutine()
+	for deadline := time.Now().Add(5 * time.Second); goroutines > baseline && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		goroutines = runtime.NumGoroutine()
+	}
+	assert.LessOrEqual(t, goroutines, baseline, "goroutines leaked")
+}
+
+func TestManager_StopForDeviceAbortsReading(t *testing.T) {
+	m, stop := newTestManager(t)
+	defer stop()
+
+	require.NoError(t, cache.Profiles().Update(models.DeviceProfile{
+		Name: testProfileName,
+		DeviceResources: []models.DeviceResource{
+			{Name: "test-resource", Properties: models.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_R}},
+		},
+	}))
+	var blocking int32
+	reading := make(chan context.Context, 1)
+	driver := &mocks.ContextualProtocolDriver{}
+	driver.On("HandleReadCommandsWithContext", mock.Anything, testDeviceName, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, _ string, _ map[string]models.ProtocolProperties, reqs []sdkModels.CommandRequest) []*sdkModels.CommandValue {
+			reading <- ctx
+			if atomic.LoadInt32(&blocking) == 1 {
+				// a device not responding until the reading is aborted
+				select {
+				case <-ctx.Done():
+				case <-time.After(5 * time.Second):
+				}
+			}
+			cv, _ := sdkModels.NewCommandValue(reqs[0].DeviceResourceName, common.ValueTypeString, "value")
+			return []*sdkModels.CommandValue{cv}
+		}, nil)
+	var sent int32
+	sink := &mocks.EventSink{}
+	sink.On("Name").Return("test-sink")
+	sink.On("Send", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		atomic.AddInt32(&sent, 1)
+	})
+	m.dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return &mocks.ProtocolDriver{}
+		},
+		container.ContextualProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+		container.EventSinksName: func(get di.Get) interface{} {
+			return []sdkModels.EventSink{sink}
+		},
+	})
+
+	// a reading completing sends its event
+	m.RestartForDevice(testDeviceName)
+	require.NoError(t, m.TriggerAutoEvent(testDeviceName, "test-resource"))
+	<-reading
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&sent) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	atomic.StoreInt32(&blocking, 1)
+	m.mutex.Lock()
+	executors := m.executorMap[testDeviceName]
+	m.mutex.Unlock()
+	require.Eventually(t, func() bool { return m.TriggerAutoEvent(testDeviceName, "test-resource") == nil }, 5*time.Second, 10*time.Millisecond)
+	ctx := <-reading
+	m.StopForDevice(testDeviceName)
+	// the reading is aborted by the time StopForDevice returns
+	assert.Error(t, ctx.Err(), "reading not aborted")
+	for _, e := range executors {
+		require.Eventually(t, func() bool { return !m.scheduler.status(e).Running }, 5*time.Second, 10*time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&sent), "event sent by a reading aborted")
+}
@@ -0,0 +1,354 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule computes the fire times of an AutoEvent.
+type schedule interface {
+	// next returns the first fire time after t, or the zero time if there is none.
+	next(t time.Time) time.Time
+}
+
+// intervalSchedule fires every interval.
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (s intervalSchedule) next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// adaptive interval factors of adaptiveSchedule
+const (
+	adaptiveBackoff = 2
+	adaptiveSpeedUp = 2
+)
+
+// adaptiveSchedule fires every interval, which adapts between min and max to the readings:
+// it is multiplied by adaptiveBackoff when a reading fails or the readings are unchanged,
+// and divided by adaptiveSpeedUp when they change. It is guarded by the scheduler mutex.
+type adaptiveSchedule struct {
+	interval time.Duration
+	min      time.Duration
+	max      time.Duration
+}
+
+func newAdaptiveSchedule(interval time.Duration, min time.Duration, max time.Duration) *adaptiveSchedule {
+	s := &adaptiveSchedule{interval: interval, min: min, max: max}
+	s.clamp()
+	return s
+}
+
+func (s *adaptiveSchedule) next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// adapt updates the interval from the result of a reading and returns whether it changed.
+func (s *adaptiveSchedule) adapt(result runResult) bool {
+	previous := s.interval
+	if result.err == nil && result.changed {
+		s.interval /= adaptiveSpeedUp
+	} else {
+		s.interval *= adaptiveBackoff
+	}
+	s.clamp()
+	return s.interval != previous
+}
+
+func (s *adaptiveSchedule) clamp() {
+	if s.interval < s.min {
+		s.interval = s.min
+	}
+	if s.interval > s.max {
+		s.interval = s.max
+	}
+}
+
+// alignedSchedule fires at the multiples of interval since midnight, e.g. on the hour and
+// every quarter hour for 15m.
+type alignedSchedule struct {
+	interval time.Duration
+	location *time.Location
+}
+
+func (s alignedSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+	next := midnight.Add((t.Sub(midnight)/s.interval + 1) * s.interval)
+	if nextMidnight := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location); !next.Before(nextMidnight) {
+		return nextMidnight
+	}
+	return next
+}
+
+// cronSchedule fires at the times matching a cron expression, each field being a bit set.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domAny and dowAny indicate the day of month or day of week field is *, a day matching
+	// either field otherwise
+	domAny, dowAny bool
+	location       *time.Location
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = cronField{min: 0, max: 59}
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week 7 is accepted for Sunday
+	dowField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseSchedule parses the schedule of an AutoEvent, which is one of:
+//
+//	<duration>            fires every duration, e.g. 10s
+//	@every <duration>     same as <duration>
+//	@aligned <duration>   fires at the multiples of duration since midnight, e.g. @aligned 15m
+//	<cron expression>     5 fields (minute hour day-of-month month day-of-week), or 6 fields
+//	                      with the leading seconds, e.g. */15 8-17 * * MON-FRI
+//	@hourly, @daily, @midnight, @weekly, @monthly, @yearly, @annually
+//
+// The aligned and cron schedules are evaluated in the local time zone, unless the schedule
+// is prefixed with CRON_TZ=<zone> or TZ=<zone>, e.g. CRON_TZ=Europe/Berlin 0 2 * * *.
+func parseSchedule(spec string) (schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if interval, err := time.ParseDuration(spec); err == nil {
+		if interval <= 0 {
+			return nil, fmt.Errorf("interval %s must be positive", spec)
+		}
+		return intervalSchedule{interval: interval}, nil
+	}
+
+	location := time.Local
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		fields := strings.SplitN(spec, " ", 2)
+		zone := fields[0][strings.Index(fields[0], "=")+1:]
+		var err error
+		if location, err = time.LoadLocation(zone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %s: %v", zone, err)
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("missing schedule after %s", fields[0])
+		}
+		spec = strings.TrimSpace(fields[1])
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty schedule")
+	}
+	switch fields[0] {
+	case "@every", "@aligned":
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s expects a duration", fields[0])
+		}
+		interval, err := time.ParseDuration(fields[1])
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid %s duration %s", fields[0], fields[1])
+		}
+		if fields[0] == "@every" {
+			return intervalSchedule{interval: interval}, nil
+		}
+		return alignedSchedule{interval: interval, location: location}, nil
+	}
+	if expression, ok := cronDescriptors[fields[0]]; ok && len(fields) == 1 {
+		fields = strings.Fields(expression)
+	}
+	return parseCron(fields, location)
+}
+
+func parseCron(fields []string, location *time.Location) (*cronSchedule, error) {
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression expects 5 or 6 fields, got %d", len(fields))
+	}
+
+	s := &cronSchedule{location: location}
+	var err error
+	if s.second, err = secondField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.minute, err = minuteField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = hourField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = domField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.month, err = monthField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = dowField.parse(fields[5]); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = fields[3] == "*" || fields[3] == "?"
+	s.dowAny = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+// parse returns the bit set of the values of a field made of comma separated *, values and
+// ranges, optionally with a step.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s", part)
+			}
+			rangePart = part[:i]
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			low, high = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = f.value(rangePart); err != nil {
+				return 0, err
+			}
+			high = low
+			if step > 1 {
+				high = f.max
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range %s", rangePart)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %s, expected %d-%d", s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// next advances t field by field, from the month down to the second, until all the fields
+// match, resetting the lower fields whenever a field is advanced.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	// an expression like 0 0 30 2 * never matches
+	yearLimit := t.Year() + 5
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		previous := t.Hour()
+		t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+		if s.skippedHour(previous, t.Hour()) {
+			break
+		}
+	}
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	for s.second&(1<<uint(t.Second())) == 0 {
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+// skippedHour indicates whether a matching hour was skipped between the previous and the
+// current hour by the switch to summer time, in which case the schedule fires at the end
+// of the gap rather than skipping the day.
+func (s *cronSchedule) skippedHour(previous int, current int) bool {
+	for hour := previous + 1; hour < current; hour++ {
+		if s.hour&(1<<uint(hour)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
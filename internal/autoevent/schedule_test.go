@@ -0,0 +1,198 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestParseSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Friday
+	from := time.Date(2022, 3, 25, 17, 52, 10, 500, time.UTC)
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []time.Time
+	}{
+		{"duration", "10s", []time.Time{from.Add(10 * time.Second), from.Add(20 * time.Second)}},
+		{"every", "@every 1m", []time.Time{from.Add(time.Minute), from.Add(2 * time.Minute)}},
+		{"aligned", "TZ=UTC @aligned 15m", []time.Time{
+			time.Date(2022, 3, 25, 18, 0, 0, 0, time.UTC),
+			time.Date(2022, 3, 25, 18, 15, 0, 0, time.UTC),
+		}},
+		{"aligned on midnight", "TZ=UTC @aligned 7h", []time.Time{
+			time.Date(2022, 3, 25, 21, 0, 0, 0, time.UTC),
+			time.Date(2022, 3, 26, 0, 0, 0, 0, time.UTC),
+		}},
+		{"quarter hour", "TZ=UTC */15 * * * *", []time.Time{
+			time.Date(2022, 3, 25, 18, 0, 0, 0, time.UTC),
+			time.Date(2022, 3, 25, 18, 15, 0, 0, time.UTC),
+		}},
+		{"business hours", "TZ=UTC 0 */30 8-17 * * MON-FRI", []time.Time{
+			time.Date(2022, 3, 28, 8, 0, 0, 0, time.UTC),
+			time.Date(2022, 3, 28, 8, 30, 0, 0, time.UTC),
+		}},
+		{"daily in time zone", "CRON_TZ=Europe/Berlin 0 2 * * *", []time.Time{
+			time.Date(2022, 3, 26, 2, 0, 0, 0, berlin),
+			// 02:00 doesn't exist on the day of the switch to summer time, fired at the end of the gap
+			time.Date(2022, 3, 27, 3, 0, 0, 0, berlin),
+			time.Date(2022, 3, 28, 2, 0, 0, 0, berlin),
+		}},
+		{"day of month or day of week", "TZ=UTC 0 0 1 * SUN", []time.Time{
+			time.Date(2022, 3, 27, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC),
+		}},
+		{"descriptor", "TZ=UTC @monthly", []time.Time{
+			time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"leap day", "TZ=UTC 0 12 29 2 *", []time.Time{
+			time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseSchedule(tt.spec)
+			require.NoError(t, err)
+			next := from
+			for _, expected := range tt.expected {
+				next = s.next(next)
+				assert.True(t, expected.Equal(next), "expected %v, got %v", expected, next)
+			}
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"negative duration", "-1s"},
+		{"invalid every", "@every often"},
+		{"unknown time zone", "CRON_TZ=Mars/Olympus 0 2 * * *"},
+		{"missing schedule after time zone", "TZ=UTC"},
+		{"too few fields", "0 2 * *"},
+		{"out of range", "0 24 * * *"},
+		{"invalid range", "0 17-8 * * *"},
+		{"invalid step", "*/0 * * * *"},
+		{"unknown name", "0 0 * * FUNDAY"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSchedule(tt.spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCronSchedule_NeverFires(t *testing.T) {
+	s, err := parseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.next(time.Now()).IsZero())
+}
+
+func TestAdaptiveSchedule(t *testing.T) {
+	s := newAdaptiveSchedule(10*time.Second, time.Second, 30*time.Second)
+	failed := runResult{err: errors.New("timeout")}
+	unchanged := runResult{sent: true}
+	changed := runResult{sent: true, changed: true}
+
+	steps := []struct {
+		result   runResult
+		expected time.Duration
+		adapted  bool
+	}{
+		{unchanged, 20 * time.Second, true},
+		{failed, 30 * time.Second, true},
+		{unchanged, 30 * time.Second, false},
+		{changed, 15 * time.Second, true},
+		{changed, 7500 * time.Millisecond, true},
+		{changed, 3750 * time.Millisecond, true},
+		{changed, 1875 * time.Millisecond, true},
+		{changed, time.Second, true},
+		{changed, time.Second, false},
+	}
+	for i, step := range steps {
+		assert.Equal(t, step.adapted, s.adapt(step.result), "step %d", i)
+		assert.Equal(t, step.expected, s.interval, "step %d", i)
+	}
+	now := time.Now()
+	assert.Equal(t, now.Add(time.Second), s.next(now))
+}
+
+func TestExecutor_MakeAdaptive(t *testing.T) {
+	tests := []struct {
+		name             string
+		interval         string
+		min              time.Duration
+		max              time.Duration
+		expectedInterval time.Duration
+		expectedErr      bool
+	}{
+		{"bounds", "10s", time.Second, time.Minute, 10 * time.Second, false},
+		{"interval clamped", "10s", time.Minute, time.Hour, time.Minute, false},
+		{"max only", "10s", 0, time.Minute, 10 * time.Second, false},
+		{"min above max", "10s", time.Minute, time.Second, 0, true},
+		{"schedule expression", "@aligned 15m", time.Second, time.Minute, 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewExecutor(context.Background(), "test-device", models.AutoEvent{SourceName: "test-resource", Interval: tt.interval})
+			require.NoError(t, err)
+			adaptErr := e.makeAdaptive(tt.min, tt.max)
+			if tt.expectedErr {
+				assert.Error(t, adaptErr)
+				return
+			}
+			require.NoError(t, adaptErr)
+			require.IsType(t, &adaptiveSchedule{}, e.schedule)
+			assert.Equal(t, tt.expectedInterval, e.schedule.(*adaptiveSchedule).interval)
+		})
+	}
+}
+
+func TestAdaptiveBounds(t *testing.T) {
+	device := models.Device{
+		Name: "test-device",
+		Protocols: map[string]models.ProtocolProperties{
+			"other": {
+				sdkCommon.MinInterval:                    "1s",
+				sdkCommon.MaxInterval:                    "1m",
+				sdkCommon.MaxInterval + ":test-resource": "1h",
+			},
+		},
+	}
+	min, max, ok := adaptiveBounds(device, "test-resource")
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, min)
+	assert.Equal(t, time.Hour, max)
+
+	min, max, ok = adaptiveBounds(device, "other-resource")
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, min)
+	assert.Equal(t, time.Minute, max)
+
+	_, _, ok = adaptiveBounds(models.Device{Name: "test-device"}, "test-resource")
+	assert.False(t, ok)
+}
@@ -0,0 +1,327 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// Device.AutoEventScheduling.MissedTicks values
+const (
+	missedTicksSkip        = "Skip"
+	missedTicksCatchUpOnce = "CatchUpOnce"
+	missedTicksCatchUpAll  = "CatchUpAll"
+)
+
+// maxMissedTicks bounds the missed fire times of an AutoEvent waiting to be caught up, as
+// well as the fire times counted at once after a stall of the scheduler.
+const maxMissedTicks = 1000
+
+var skippedTicks = telemetry.NewMetric("AutoEventTicksSkipped")
+
+// scheduler fires the executors of all the AutoEvents from a single goroutine, waiting for
+// the earliest fire time of a heap of executors. The next fire time of an executor follows
+// its previous fire time rather than the end of the reading, so that the readings don't
+// drift, and a reading is never started while the previous one of the same executor is in
+// progress, the fire times missed meanwhile being handled by the missed ticks policy.
+type scheduler struct {
+	policy    string
+	jitter    time.Duration
+	executors executorHeap
+	run       func(e *Executor) runResult
+	wake      chan struct{}
+	mutex     sync.Mutex
+}
+
+func newScheduler(info config.AutoEventSchedulingInfo, run func(e *Executor) runResult) (*scheduler, errors.EdgeX) {
+	s := &scheduler{run: run, wake: make(chan struct{}, 1)}
+	switch info.MissedTicks {
+	case "":
+		s.policy = missedTicksSkip
+	case missedTicksSkip, missedTicksCatchUpOnce, missedTicksCatchUpAll:
+		s.policy = info.MissedTicks
+	default:
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.AutoEventScheduling.MissedTicks %s", info.MissedTicks), nil)
+	}
+	if info.StartupJitter != "" {
+		jitter, err := time.ParseDuration(info.StartupJitter)
+		if err != nil || jitter < 0 {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.AutoEventScheduling.StartupJitter %s", info.StartupJitter), err)
+		}
+		s.jitter = jitter
+	}
+	return s, nil
+}
+
+// start fires the executors until ctx is done.
+func (s *scheduler) start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.NewTimer(time.Hour)
+		defer timer.Stop()
+		for {
+			s.mutex.Lock()
+			wait := s.fireDue(time.Now())
+			s.mutex.Unlock()
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// add schedules the executor, delaying its first fire time by the phase of the device when
+// its schedule is an interval.
+func (s *scheduler) add(e *Executor, jitter time.Duration) {
+	start := time.Now()
+	switch e.schedule.(type) {
+	case intervalSchedule, *adaptiveSchedule:
+		start = start.Add(phase(e.deviceName, jitter))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e.next = e.schedule.next(start)
+	if e.next.IsZero() {
+		return
+	}
+	heap.Push(&s.executors, e)
+	s.notify()
+}
+
+// remove unschedules the executor, letting its reading in progress, if any, complete.
+func (s *scheduler) remove(e *Executor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e.removed = true
+	if e.index >= 0 {
+		heap.Remove(&s.executors, e.index)
+	}
+}
+
+// pause stops the scheduled readings of the executor until it is resumed.
+func (s *scheduler) pause(e *Executor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e.paused = true
+}
+
+// resume restarts the scheduled readings of the executor.
+func (s *scheduler) resume(e *Executor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e.paused = false
+}
+
+// trigger starts a reading of the executor now, and returns false if a reading is already
+// in progress.
+func (s *scheduler) trigger(e *Executor) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if e.running || e.removed {
+		return false
+	}
+	s.startRun(e)
+	return true
+}
+
+// status returns the runtime state of the executor.
+func (s *scheduler) status(e *Executor) dtos.AutoEventStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status := dtos.AutoEventStatus{
+		DeviceName:       e.deviceName,
+		SourceName:       e.sourceName,
+		Interval:         e.interval,
+		OnChange:         e.onChange,
+		Paused:           e.paused,
+		Running:          e.running,
+		Runs:             e.runs,
+		Errors:           e.failures,
+		EventsSent:       e.sent,
+		EventsSuppressed: e.suppressed,
+		MissedTicks:      e.missedTicks,
+	}
+	if !e.lastRun.IsZero() {
+		status.LastRun = e.lastRun.UnixNano()
+	}
+	if e.runs > 0 && !e.running {
+		status.LastDuration = e.lastDuration.String()
+	}
+	if e.lastErr != nil {
+		status.LastError = e.lastErr.Error()
+	}
+	if e.index >= 0 && !e.paused {
+		status.NextRun = e.next.UnixNano()
+	}
+	if adaptive, ok := e.schedule.(*adaptiveSchedule); ok {
+		status.CurrentInterval = adaptive.interval.String()
+	}
+	return status
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// fireDue fires the executors whose fire time is due and returns the duration until the
+// next fire time. The mutex must be held.
+func (s *scheduler) fireDue(now time.Time) time.Duration {
+	for len(s.executors) > 0 && !s.executors[0].next.After(now) {
+		e := s.executors[0]
+		due := 0
+		next := e.next
+		for !next.IsZero() && !next.After(now) {
+			due++
+			if due > maxMissedTicks {
+				next = e.schedule.next(now)
+				break
+			}
+			next = e.schedule.next(next)
+		}
+
+		switch {
+		case e.paused:
+		case e.running:
+			e.missed += due
+			e.missedTicks += int64(due)
+		default:
+			e.missed += due - 1
+			e.missedTicks += int64(due - 1)
+			s.startRun(e)
+		}
+		if e.missed > maxMissedTicks {
+			skippedTicks.Add(int64(e.missed - maxMissedTicks))
+			e.missed = maxMissedTicks
+		}
+
+		if next.IsZero() {
+			heap.Pop(&s.executors)
+			continue
+		}
+		e.next = next
+		heap.Fix(&s.executors, 0)
+	}
+
+	if len(s.executors) == 0 {
+		return time.Hour
+	}
+	return time.Until(s.executors[0].next)
+}
+
+// startRun starts a reading of the executor. The mutex must be held.
+func (s *scheduler) startRun(e *Executor) {
+	e.running = true
+	e.lastRun = time.Now()
+	go func() {
+		s.completeRun(e, s.run(e))
+	}()
+}
+
+// completeRun records the outcome of a reading of the executor, adapts its interval if it
+// is adaptive and applies the missed ticks policy.
+func (s *scheduler) completeRun(e *Executor, result runResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	e.running = false
+	e.runs++
+	e.lastDuration = time.Since(e.lastRun)
+	e.lastErr = result.err
+	switch {
+	case result.err != nil:
+		e.failures++
+	case result.sent:
+		e.sent++
+	default:
+		e.suppressed++
+	}
+	if adaptive, ok := e.schedule.(*adaptiveSchedule); ok && adaptive.adapt(result) && e.index >= 0 {
+		e.next = adaptive.next(e.lastRun)
+		heap.Fix(&s.executors, e.index)
+		s.notify()
+	}
+	if e.removed || e.paused || e.missed == 0 {
+		e.missed = 0
+		return
+	}
+
+	switch s.policy {
+	case missedTicksCatchUpOnce:
+		skippedTicks.Add(int64(e.missed - 1))
+		e.missed = 0
+		s.startRun(e)
+	case missedTicksCatchUpAll:
+		e.missed--
+		s.startRun(e)
+	default:
+		skippedTicks.Add(int64(e.missed))
+		e.missed = 0
+	}
+}
+
+// phase returns the delay of the first fire time of the AutoEvents of the device, which is
+// derived from the device name to spread the devices evenly over the jitter.
+func phase(deviceName string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(deviceName))
+	return time.Duration(h.Sum64() % uint64(jitter))
+}
+
+// executorHeap orders the executors by their next fire time.
+type executorHeap []*Executor
+
+func (h executorHeap) Len() int           { return len(h) }
+func (h executorHeap) Less(i, j int) bool { return h[i].next.Before(h[j].next) }
+func (h executorHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *executorHeap) Push(x interface{}) {
+	e := x.(*Executor)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *executorHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*h = old[:len(old)-1]
+	return e
+}
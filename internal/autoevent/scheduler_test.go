@@ -0,0 +1,236 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+)
+
+func testExecutor(deviceName string, interval time.Duration) *Executor {
+	return &Executor{deviceName: deviceName, sourceName: "test-resource", schedule: intervalSchedule{interval: interval}, mutex: &sync.Mutex{}, index: -1}
+}
+
+func TestScheduler_MissedTicks(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          string
+		expectedCatchUp int
+	}{
+		{"Skip", missedTicksSkip, 0},
+		{"default", "", 0},
+		{"CatchUpOnce", missedTicksCatchUpOnce, 1},
+		{"CatchUpAll", missedTicksCatchUpAll, 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{}, 10)
+			release := make(chan struct{})
+			s, err := newScheduler(config.AutoEventSchedulingInfo{MissedTicks: tt.policy}, func(e *Executor) runResult {
+				started <- struct{}{}
+				<-release
+				return runResult{sent: true, changed: true}
+			})
+			require.NoError(t, err)
+
+			start := time.Now().Add(time.Hour)
+			e := testExecutor("test-device", 10*time.Millisecond)
+			s.mutex.Lock()
+			e.next = start
+			s.executors = executorHeap{e}
+			e.index = 0
+			s.fireDue(start)
+			s.mutex.Unlock()
+			<-started
+
+			// the fire times at +10ms, +20ms and +30ms are missed by the reading in progress
+			s.mutex.Lock()
+			s.fireDue(start.Add(35 * time.Millisecond))
+			assert.Equal(t, 3, e.missed)
+			assert.Equal(t, start.Add(40*time.Millisecond), e.next)
+			s.mutex.Unlock()
+
+			catchUp := 0
+			release <- struct{}{}
+			for {
+				select {
+				case <-started:
+					catchUp++
+					release <- struct{}{}
+					continue
+				case <-time.After(100 * time.Millisecond):
+				}
+				break
+			}
+			assert.Equal(t, tt.expectedCatchUp, catchUp)
+			s.mutex.Lock()
+			assert.Zero(t, e.missed)
+			assert.False(t, e.running)
+			s.mutex.Unlock()
+		})
+	}
+}
+
+func TestScheduler_FixedRate(t *testing.T) {
+	var mutex sync.Mutex
+	var fired []time.Time
+	s, err := newScheduler(config.AutoEventSchedulingInfo{}, func(e *Executor) runResult {
+		mutex.Lock()
+		fired = append(fired, time.Now())
+		mutex.Unlock()
+		// a slow reading doesn't delay the next fire time
+		time.Sleep(5 * time.Millisecond)
+		return runResult{sent: true, changed: true}
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	s.start(ctx, wg)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	e := testExecutor("test-device", 20*time.Millisecond)
+	begin := time.Now()
+	s.add(e, 0)
+	time.Sleep(210 * time.Millisecond)
+	s.remove(e)
+	mutex.Lock()
+	count := len(fired)
+	last := fired[len(fired)-1]
+	mutex.Unlock()
+
+	assert.InDelta(t, 10, count, 2)
+	// the 10th reading is close to 200ms after the start rather than 10 readings of 25ms
+	assert.Less(t, last.Sub(begin), 240*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+	mutex.Lock()
+	assert.Equal(t, count, len(fired), "removed executor fired")
+	mutex.Unlock()
+}
+
+func TestPhase(t *testing.T) {
+	jitter := 10 * time.Second
+	assert.Zero(t, phase("test-device", 0))
+	assert.Equal(t, phase("test-device", jitter), phase("test-device", jitter))
+
+	phases := make(map[time.Duration]bool)
+	for _, name := range []string{"device-1", "device-2", "device-3", "device-4"} {
+		p := phase(name, jitter)
+		assert.GreaterOrEqual(t, p, time.Duration(0))
+		assert.Less(t, p, jitter)
+		phases[p] = true
+	}
+	assert.Len(t, phases, 4, "devices are not spread")
+}
+
+func TestNewScheduler_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		info config.AutoEventSchedulingInfo
+	}{
+		{"unsupported MissedTicks", config.AutoEventSchedulingInfo{MissedTicks: "CatchUpSome"}},
+		{"invalid StartupJitter", config.AutoEventSchedulingInfo{StartupJitter: "soon"}},
+		{"negative StartupJitter", config.AutoEventSchedulingInfo{StartupJitter: "-1s"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newScheduler(tt.info, func(e *Executor) runResult { return runResult{} })
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScheduler_PauseAndTrigger(t *testing.T) {
+	fired := make(chan struct{}, 10)
+	s, err := newScheduler(config.AutoEventSchedulingInfo{}, func(e *Executor) runResult {
+		fired <- struct{}{}
+		return runResult{}
+	})
+	require.NoError(t, err)
+
+	start := time.Now().Add(time.Hour)
+	e := testExecutor("test-device", 10*time.Millisecond)
+	s.mutex.Lock()
+	e.next = start
+	s.executors = executorHeap{e}
+	e.index = 0
+	s.mutex.Unlock()
+
+	s.pause(e)
+	s.mutex.Lock()
+	s.fireDue(start)
+	s.mutex.Unlock()
+	status := s.status(e)
+	assert.True(t, status.Paused)
+	assert.Zero(t, status.NextRun)
+	assert.Zero(t, status.MissedTicks, "paused ticks are not missed")
+
+	// a paused AutoEvent can still be triggered
+	require.True(t, s.trigger(e))
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		require.Fail(t, "AutoEvent not triggered")
+	}
+	assert.Eventually(t, func() bool {
+		return s.status(e).Runs == 1
+	}, time.Second, time.Millisecond)
+	status = s.status(e)
+	assert.Equal(t, int64(1), status.EventsSuppressed)
+	assert.NotEmpty(t, status.LastDuration)
+	assert.NotZero(t, status.LastRun)
+
+	s.resume(e)
+	s.mutex.Lock()
+	s.fireDue(start.Add(10 * time.Millisecond))
+	s.mutex.Unlock()
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		require.Fail(t, "resumed AutoEvent not fired")
+	}
+	assert.Equal(t, start.Add(20*time.Millisecond).UnixNano(), s.status(e).NextRun)
+}
+
+func TestScheduler_Adaptive(t *testing.T) {
+	done := make(chan struct{}, 1)
+	s, err := newScheduler(config.AutoEventSchedulingInfo{}, func(e *Executor) runResult {
+		defer func() { done <- struct{}{} }()
+		return runResult{sent: true}
+	})
+	require.NoError(t, err)
+
+	e := testExecutor("test-device", 10*time.Second)
+	require.NoError(t, e.makeAdaptive(time.Second, time.Minute))
+	start := time.Now().Add(time.Hour)
+	s.mutex.Lock()
+	e.next = start
+	s.executors = executorHeap{e}
+	e.index = 0
+	s.fireDue(start)
+	s.mutex.Unlock()
+	<-done
+
+	// the unchanged readings double the interval from the start of the reading
+	assert.Eventually(t, func() bool {
+		return s.status(e).CurrentInterval == "20s"
+	}, time.Second, time.Millisecond)
+	s.mutex.Lock()
+	assert.Equal(t, e.lastRun.Add(20*time.Second), e.next)
+	s.mutex.Unlock()
+}
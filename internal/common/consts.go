@@ -8,6 +8,10 @@
 
 package common
 
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+)
+
 const (
 	EnvInstanceName   = "EDGEX_INSTANCE_NAME"
 	ConfigStemDevice  = "edgex/devices/"
@@ -15,6 +19,104 @@ const (
 	SDKReservedPrefix = "ds-"
 )
 
+// SDK reserved routes
+const (
+	// ApiDeviceCommandBatchRoute executes the device commands of many devices in one request
+	ApiDeviceCommandBatchRoute = common.ApiBase + "/device/command/batch"
+	// ApiAutoEventRoute is the base route of the AutoEvent introspection and control
+	ApiAutoEventRoute = common.ApiBase + "/autoevent"
+	// ApiAllAutoEventRoute lists the status of the AutoEvents of all the devices
+	ApiAllAutoEventRoute = ApiAutoEventRoute + "/" + common.All
+	// ApiAutoEventByDeviceNameRoute lists the status of the AutoEvents of a device
+	ApiAutoEventByDeviceNameRoute = ApiAutoEventRoute + "/device/" + common.Name + "/{" + common.Name + "}"
+	// ApiAutoEventPauseRoute pauses the AutoEvents of a device source
+	ApiAutoEventPauseRoute = ApiAutoEventByDeviceNameRoute + "/source/{" + common.SourceName + "}/pause"
+	// ApiAutoEventResumeRoute resumes the AutoEvents of a device source
+	ApiAutoEventResumeRoute = ApiAutoEventByDeviceNameRoute + "/source/{" + common.SourceName + "}/resume"
+	// ApiAutoEventTriggerRoute triggers an immediate reading of the AutoEvents of a device source
+	ApiAutoEventTriggerRoute = ApiAutoEventByDeviceNameRoute + "/source/{" + common.SourceName + "}/trigger"
+)
+
+// SDK reserved query parameters
+const (
+	// Timeout is the query parameter to specify the deadline of a device command as a duration string
+	Timeout = SDKReservedPrefix + "timeout"
+	// MaxAge is the query parameter, as well as the device resource attribute, to specify the
+	// max age of a cached reading which can be returned by a GET command as a duration string
+	MaxAge = SDKReservedPrefix + "maxage"
+	// Verify is the query parameter, as well as the device resource attribute, to enable the
+	// read-back verification of SET commands, and the tag of the event published on mismatch.
+	// It is set per device command with the device protocol property ds-verify:<command>.
+	Verify = SDKReservedPrefix + "verify"
+	// VerifyTolerance is the device resource attribute overriding Device.Verify.Tolerance
+	VerifyTolerance = SDKReservedPrefix + "verifytolerance"
+	// Transaction is the query parameter to execute a SET command as a transaction which is
+	// rolled back on failure
+	Transaction = SDKReservedPrefix + "transaction"
+)
+
+// SDK reserved device resource attributes
+const (
+	// Deadband is the absolute change of a numeric reading since the last reported one
+	// below which the readings published by AutoEvents and async readings are dropped
+	Deadband = SDKReservedPrefix + "deadband"
+	// DeadbandPercent is the change of a numeric reading, in percent of the last reported
+	// one, below which the readings are dropped
+	DeadbandPercent = SDKReservedPrefix + "deadbandpercent"
+	// MaxSilence is the duration after which a reading is reported regardless of the deadband
+	MaxSilence = SDKReservedPrefix + "maxsilence"
+)
+
+// SDK reserved event tags
+const (
+	// FailedResources is the event tag listing the device resources which failed to be read
+	// along with their error, when the other device resources of the command were read
+	FailedResources = SDKReservedPrefix + "failedresources"
+)
+
+// SDK reserved device protocol properties
+const (
+	// ConcurrencyKey groups devices sharing the same value into one command limiter
+	ConcurrencyKey = SDKReservedPrefix + "concurrencykey"
+	// MaxConcurrent overrides Device.Concurrency.MaxConcurrent for the device
+	MaxConcurrent = SDKReservedPrefix + "maxconcurrent"
+	// MaxQueueDepth overrides Device.Concurrency.MaxQueueDepth for the device
+	MaxQueueDepth = SDKReservedPrefix + "maxqueuedepth"
+	// BatchWindow overrides Device.ReadBatching.Window for the device
+	BatchWindow = SDKReservedPrefix + "batchwindow"
+	// BatchMaxRequests overrides Device.ReadBatching.MaxRequests for the device
+	BatchMaxRequests = SDKReservedPrefix + "batchmaxrequests"
+	// HealthResource overrides Device.Health.ProbeResource for the device
+	HealthResource = SDKReservedPrefix + "healthresource"
+	// Schedule, suffixed with ":<source name>", overrides the Interval of the AutoEvent of the
+	// source with a schedule expression, which core-metadata rejects in the Interval itself
+	Schedule = SDKReservedPrefix + "schedule"
+	// StartupJitter overrides Device.AutoEventScheduling.StartupJitter for the device
+	StartupJitter = SDKReservedPrefix + "startupjitter"
+	// MinInterval and MaxInterval, optionally suffixed with ":<source name>", make the interval
+	// of the AutoEvents of the device, or of the source, adapt to the readings within these
+	// bounds, each defaulting to the Interval of the AutoEvent
+	MinInterval = SDKReservedPrefix + "mininterval"
+	MaxInterval = SDKReservedPrefix + "maxinterval"
+)
+
+// Event publishing QoS, see Device.EventPublish.QoS
+const (
+	// QoSFireAndForget publishes an event once, a failed event is lost unless it is stored
+	QoSFireAndForget = "FireAndForget"
+	// QoSAtLeastOnce retries the publishing of an event until it succeeds or the attempts
+	// are used up, so that the event may be received more than once
+	QoSAtLeastOnce = "AtLeastOnce"
+)
+
+// Binary value transfer modes, see Device.BinaryStream.Mode
+const (
+	// BinaryStreamObjectStore writes each streamed value to the object store
+	BinaryStreamObjectStore = "ObjectStore"
+	// BinaryStreamChunked publishes each streamed value in chunks to the MessageBus
+	BinaryStreamChunked = "Chunked"
+)
+
 // SDKVersion indicates the version of the SDK - will be overwritten by build
 var SDKVersion string = "0.0.0"
 
@@ -0,0 +1,325 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// ContentTypeEventBatch is the content type of a MessageBus envelope holding a JSON array of
+// AddEventRequest. The compression of the payload, if any, is given by the compression
+// parameter, e.g. "application/vnd.edgex.event-batch+json; compression=gzip".
+const ContentTypeEventBatch = "application/vnd.edgex.event-batch+json"
+
+// Compressor returns a writer compressing the data written to it into w.
+type Compressor func(w io.Writer) (io.WriteCloser, error)
+
+var compressors = map[string]Compressor{
+	"gzip": func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	},
+	"zstd": func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w)
+	},
+}
+
+var compressorsMutex sync.RWMutex
+
+// RegisterCompressor makes the named compression available to Device.EventBatching.Compression,
+// in addition to the builtin gzip and zstd.
+func RegisterCompressor(name string, compressor Compressor) {
+	compressorsMutex.Lock()
+	defer compressorsMutex.Unlock()
+	compressors[name] = compressor
+}
+
+func compressorFor(name string) (Compressor, bool) {
+	compressorsMutex.RLock()
+	defer compressorsMutex.RUnlock()
+	compressor, ok := compressors[name]
+	return compressor, ok
+}
+
+var batcher = &eventBatcher{batches: make(map[string]*eventBatch), published: make(map[string]chan struct{})}
+
+// eventBatcher accumulates the events published to the same MessageBus topic and publishes
+// them as a single envelope, see Device.EventBatching. It is disabled until started.
+type eventBatcher struct {
+	enabled    bool
+	window     time.Duration
+	maxEvents  int
+	maxBytes   int
+	compressor Compressor
+	encoding   string
+	dic        *di.Container
+	// ctx is done once the device service stops, aborting the retries of publishing
+	ctx     context.Context
+	batches map[string]*eventBatch
+	// published holds, for each topic, the channel closed once the last batch flushed is
+	// published, so that the batches of a topic are published in the order they were flushed
+	published map[string]chan struct{}
+	// publishing counts the batches being published, which are waited for on stop
+	publishing sync.WaitGroup
+	mutex      sync.Mutex
+}
+
+type eventBatch struct {
+	events   []sdkModels.StoredEvent
+	outcomes []func(error)
+	requests []json.RawMessage
+	size     int
+	timer    *time.Timer
+}
+
+// StartEventBatcher enables the batching of the events published to the MessageBus when
+// Device.EventBatching.Window is set. The pending batches are published once ctx is done.
+func StartEventBatcher(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	configuration := container.ConfigurationFrom(dic.Get)
+	info := configuration.Device.EventBatching
+	if !configuration.Device.UseMessageBus || info.Window == "" {
+		return nil
+	}
+
+	window, err := time.ParseDuration(info.Window)
+	if err != nil || window <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.EventBatching.Window %s", info.Window), err)
+	}
+	var compressor Compressor
+	if info.Compression != "" {
+		var ok bool
+		if compressor, ok = compressorFor(info.Compression); !ok {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.EventBatching.Compression %s", info.Compression), nil)
+		}
+	}
+
+	batcher.mutex.Lock()
+	batcher.enabled = true
+	batcher.window = window
+	batcher.maxEvents = info.MaxEvents
+	batcher.maxBytes = info.MaxBytes
+	batcher.compressor = compressor
+	batcher.encoding = info.Compression
+	batcher.dic = dic
+	batcher.ctx = ctx
+	batcher.mutex.Unlock()
+	lc.Infof("Batching of events published to the MessageBus enabled, window %s", window)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		batcher.stop()
+	}()
+
+	return nil
+}
+
+// add appends the event to the batch of its topic, and returns false if batching is disabled.
+// The outcome of the event is reported once its batch is published.
+func (b *eventBatcher) add(event *dtos.Event, correlationID string, topic string, outcome func(error)) (bool, error) {
+	b.mutex.Lock()
+	enabled := b.enabled
+	b.mutex.Unlock()
+	if !enabled {
+		return false, nil
+	}
+	req, err := json.Marshal(requests.NewAddEventRequest(*event))
+	if err != nil {
+		return true, permanentError{fmt.Errorf("failed to encode event: %v", err)}
+	}
+
+	b.mutex.Lock()
+	if !b.enabled {
+		b.mutex.Unlock()
+		return false, nil
+	}
+	batch, ok := b.batches[topic]
+	if !ok {
+		batch = &eventBatch{}
+		b.batches[topic] = batch
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.flush(topic, batch)
+		})
+	}
+	batch.events = append(batch.events, sdkModels.StoredEvent{Event: *event, CorrelationID: correlationID, Stored: time.Now().UnixNano()})
+	batch.outcomes = append(batch.outcomes, outcome)
+	batch.requests = append(batch.requests, req)
+	batch.size += len(req)
+	var publish func()
+	if (b.maxEvents > 0 && len(batch.events) >= b.maxEvents) || (b.maxBytes > 0 && batch.size >= b.maxBytes) {
+		publish = b.detach(topic, batch)
+	}
+	b.mutex.Unlock()
+
+	if publish != nil {
+		// published in the background, as the batches flushed by their timer, so that a slow
+		// MessageBus does not block the command or AutoEvent sending the event
+		go publish()
+	}
+	return true, nil
+}
+
+// flush publishes the batch unless it was already flushed.
+func (b *eventBatcher) flush(topic string, batch *eventBatch) {
+	b.mutex.Lock()
+	publish := b.detach(topic, batch)
+	b.mutex.Unlock()
+	if publish != nil {
+		publish()
+	}
+}
+
+// detach removes the batch from the pending ones and returns the function publishing it
+// after the batches of the topic detached before, or nil if it was already detached. The
+// mutex must be held.
+func (b *eventBatcher) detach(topic string, batch *eventBatch) func() {
+	if b.batches[topic] != batch {
+		return nil
+	}
+	delete(b.batches, topic)
+	batch.timer.Stop()
+	ctx, compressor, encoding, dic := b.ctx, b.compressor, b.encoding, b.dic
+	previous := b.published[topic]
+	published := make(chan struct{})
+	b.published[topic] = published
+	b.publishing.Add(1)
+	return func() {
+		defer b.publishing.Done()
+		defer func() {
+			b.mutex.Lock()
+			if b.published[topic] == published {
+				delete(b.published, topic)
+			}
+			b.mutex.Unlock()
+			close(published)
+		}()
+		// the previous batch of the topic is waited for without holding the mutex, so that
+		// the events of the other topics are still added meanwhile
+		if previous != nil {
+			<-previous
+		}
+		b.publish(ctx, topic, batch, compressor, encoding, dic)
+	}
+}
+
+// publish publishes the batch, storing it for replay on failure if Device.StoreAndForward
+// is enabled, and reports the outcome of its events.
+func (b *eventBatcher) publish(ctx context.Context, topic string, batch *eventBatch, compressor Compressor, encoding string, dic *di.Container) {
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	err := publishWithQoS(ctx, len(batch.events), dic, func() error {
+		return publishBatch(batch, topic, compressor, encoding, dic)
+	})
+	if err == nil {
+		lc.Debugf("Batch of %d events published to MessageBus topic %s", len(batch.events), topic)
+		for _, outcome := range batch.outcomes {
+			reportOutcome(outcome, nil)
+		}
+		return
+	}
+
+	store := container.EventStoreFrom(dic.Get)
+	if store == nil || isPermanent(err) {
+		lc.Errorf("Dropped batch of %d events: %v", len(batch.events), err)
+		for _, outcome := range batch.outcomes {
+			reportOutcome(outcome, err)
+		}
+		return
+	}
+	lc.Warnf("%v; storing the batch of %d events for replay", err, len(batch.events))
+	storeErrors := make([]error, len(batch.events))
+	forwarder.mutex.Lock()
+	for i, event := range batch.events {
+		storeErrors[i] = forwarder.store(event, store, dic)
+	}
+	forwarder.mutex.Unlock()
+	for i, outcome := range batch.outcomes {
+		reportStored(outcome, storeErrors[i])
+	}
+}
+
+// stop disables batching, publishes the pending batches and waits for the batches being
+// published.
+func (b *eventBatcher) stop() {
+	b.mutex.Lock()
+	b.enabled = false
+	pending := make(map[string]*eventBatch, len(b.batches))
+	for topic, batch := range b.batches {
+		pending[topic] = batch
+	}
+	b.mutex.Unlock()
+
+	for topic, batch := range pending {
+		b.flush(topic, batch)
+	}
+	b.publishing.Wait()
+}
+
+func publishBatch(batch *eventBatch, topic string, compressor Compressor, encoding string, dic *di.Container) error {
+	var payload bytes.Buffer
+	var w io.Writer = &payload
+	contentType := ContentTypeEventBatch
+	var cw io.WriteCloser
+	if compressor != nil {
+		var err error
+		if cw, err = compressor(&payload); err != nil {
+			return permanentError{fmt.Errorf("failed to compress batch of events: %v", err)}
+		}
+		w = cw
+		contentType = fmt.Sprintf("%s; compression=%s", ContentTypeEventBatch, encoding)
+	}
+
+	_, err := w.Write([]byte{'['})
+	for i, req := range batch.requests {
+		if err == nil && i > 0 {
+			_, err = w.Write([]byte{','})
+		}
+		if err == nil {
+			_, err = w.Write(req)
+		}
+	}
+	if err == nil {
+		_, err = w.Write([]byte{']'})
+	}
+	if err == nil && cw != nil {
+		err = cw.Close()
+	}
+	if err != nil {
+		return permanentError{fmt.Errorf("failed to encode batch of events: %v", err)}
+	}
+
+	envelope := types.MessageEnvelope{
+		CorrelationID: uuid.NewString(),
+		Payload:       payload.Bytes(),
+		ContentType:   contentType,
+	}
+	err = container.MessagingClientFrom(dic.Get).Publish(envelope, topic)
+	if err != nil {
+		return fmt.Errorf("failed to publish batch of events to MessageBus: %v", err)
+	}
+	return nil
+}
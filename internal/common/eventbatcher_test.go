@@ -0,0 +1,232 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+type publishedEnvelope struct {
+	envelope types.MessageEnvelope
+	topic    string
+}
+
+// messageClient is a MessageClient capturing the published envelopes. The publishing to
+// blockedTopic, if set, is signalled on blocking and waits until unblock is closed.
+type messageClient struct {
+	published    chan publishedEnvelope
+	blockedTopic string
+	blocking     chan struct{}
+	unblock      chan struct{}
+}
+
+func (c *messageClient) Connect() error {
+	return nil
+}
+
+func (c *messageClient) Publish(message types.MessageEnvelope, topic string) error {
+	if c.blockedTopic != "" && topic == c.blockedTopic {
+		c.blocking <- struct{}{}
+		<-c.unblock
+	}
+	c.published <- publishedEnvelope{envelope: message, topic: topic}
+	return nil
+}
+
+func (c *messageClient) Subscribe(_ []types.TopicChannel, _ chan error) error {
+	return nil
+}
+
+func (c *messageClient) Disconnect() error {
+	return nil
+}
+
+func startEventBatcher(t *testing.T, info config.EventBatchingInfo) (*di.Container, *messageClient, func()) {
+	mc := &messageClient{published: make(chan publishedEnvelope, 10)}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Device:       config.DeviceInfo{UseMessageBus: true, EventBatching: info},
+				MessageQueue: bootstrapConfig.MessageBusInfo{PublishTopicPrefix: "edgex/events/device"},
+			}
+		},
+		container.MessagingClientName: func(get di.Get) interface{} {
+			return mc
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	err := StartEventBatcher(ctx, wg, dic)
+	require.NoError(t, err)
+	return dic, mc, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func receiveBatch(t *testing.T, mc *messageClient, timeout time.Duration) (string, []string, string) {
+	var published publishedEnvelope
+	select {
+	case published = <-mc.published:
+	case <-time.After(timeout):
+		require.Fail(t, "batch not published")
+	}
+
+	payload := published.envelope.Payload
+	switch published.envelope.ContentType {
+	case ContentTypeEventBatch + "; compression=gzip":
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		require.NoError(t, err)
+		payload, err = io.ReadAll(r)
+		require.NoError(t, err)
+	case ContentTypeEventBatch + "; compression=zstd":
+		r, err := zstd.NewReader(nil)
+		require.NoError(t, err)
+		defer r.Close()
+		payload, err = r.DecodeAll(payload, nil)
+		require.NoError(t, err)
+	}
+	// AddEventRequest validates on unmarshal, only the event IDs matter here
+	var reqs []struct{ Event struct{ Id string } }
+	require.NoError(t, json.Unmarshal(payload, &reqs))
+	ids := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.Event.Id)
+	}
+	return published.topic, ids, published.envelope.ContentType
+}
+
+func testEvent(id string, deviceName string) *dtos.Event {
+	return &dtos.Event{Id: id, ProfileName: "test-profile", DeviceName: deviceName, SourceName: "test-resource"}
+}
+
+func TestEventBatcher(t *testing.T) {
+	tests := []struct {
+		name                string
+		info                config.EventBatchingInfo
+		expectedContentType string
+	}{
+		{"MaxEvents", config.EventBatchingInfo{Window: "1h", MaxEvents: 3}, ContentTypeEventBatch},
+		{"MaxBytes", config.EventBatchingInfo{Window: "1h", MaxBytes: 1}, ContentTypeEventBatch},
+		{"gzip compression", config.EventBatchingInfo{Window: "1h", MaxEvents: 3, Compression: "gzip"}, ContentTypeEventBatch + "; compression=gzip"},
+		{"zstd compression", config.EventBatchingInfo{Window: "1h", MaxEvents: 3, Compression: "zstd"}, ContentTypeEventBatch + "; compression=zstd"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic, mc, stop := startEventBatcher(t, tt.info)
+			defer stop()
+
+			if tt.info.MaxBytes > 0 {
+				// every event exceeds MaxBytes on its own
+				SendEvent(testEvent("1", "test-device"), "", dic)
+				_, ids, _ := receiveBatch(t, mc, time.Second)
+				assert.Equal(t, []string{"1"}, ids)
+				return
+			}
+
+			SendEvent(testEvent("1", "test-device"), "", dic)
+			SendEvent(testEvent("2", "other-device"), "", dic)
+			SendEvent(testEvent("3", "test-device"), "", dic)
+			SendEvent(testEvent("4", "test-device"), "", dic)
+			topic, ids, contentType := receiveBatch(t, mc, time.Second)
+			assert.Equal(t, "edgex/events/device/test-profile/test-device/test-resource", topic)
+			assert.Equal(t, []string{"1", "3", "4"}, ids)
+			assert.Equal(t, tt.expectedContentType, contentType)
+
+			// the pending batches are published on stop
+			stop()
+			topic, ids, _ = receiveBatch(t, mc, time.Second)
+			assert.Equal(t, "edgex/events/device/test-profile/other-device/test-resource", topic)
+			assert.Equal(t, []string{"2"}, ids)
+		})
+	}
+}
+
+func TestEventBatcher_Window(t *testing.T) {
+	dic, mc, stop := startEventBatcher(t, config.EventBatchingInfo{Window: "50ms", MaxEvents: 100})
+	defer stop()
+
+	SendEvent(testEvent("1", "test-device"), "", dic)
+	SendEvent(testEvent("2", "test-device"), "", dic)
+	_, ids, _ := receiveBatch(t, mc, time.Second)
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestEventBatcher_BlockedTopic(t *testing.T) {
+	dic, mc, stop := startEventBatcher(t, config.EventBatchingInfo{Window: "1h", MaxEvents: 1})
+	defer stop()
+	mc.blockedTopic = "edgex/events/device/test-profile/test-device/test-resource"
+	mc.blocking = make(chan struct{}, 2)
+	mc.unblock = make(chan struct{})
+
+	// the events of a full batch are sent without waiting for it to be published
+	SendEvent(testEvent("1", "test-device"), "", dic)
+	<-mc.blocking
+	SendEvent(testEvent("2", "test-device"), "", dic)
+
+	// the events of the other topics are published while a topic is blocked
+	SendEvent(testEvent("3", "other-device"), "", dic)
+	topic, ids, _ := receiveBatch(t, mc, time.Second)
+	assert.Equal(t, "edgex/events/device/test-profile/other-device/test-resource", topic)
+	assert.Equal(t, []string{"3"}, ids)
+
+	// the batches of the blocked topic are published in order once it is unblocked
+	close(mc.unblock)
+	_, ids, _ = receiveBatch(t, mc, time.Second)
+	assert.Equal(t, []string{"1"}, ids)
+	_, ids, _ = receiveBatch(t, mc, time.Second)
+	assert.Equal(t, []string{"2"}, ids)
+}
+
+func TestStartEventBatcher_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		info config.EventBatchingInfo
+	}{
+		{"invalid Window", config.EventBatchingInfo{Window: "invalid"}},
+		{"unsupported Compression", config.EventBatchingInfo{Window: "1s", Compression: "lz4"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{UseMessageBus: true, EventBatching: tt.info}}
+				},
+			})
+			err := StartEventBatcher(context.Background(), &sync.WaitGroup{}, dic)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var failedSinkEvents = telemetry.NewMetric("EventSinkFailed")
+
+// filteredEventSink is an EventSink receiving only the events passing its filter.
+type filteredEventSink struct {
+	sdkModels.EventSink
+	filter sdkModels.EventSinkFilter
+}
+
+// NewFilteredEventSink returns an EventSink sending to sink the events passing the filter.
+func NewFilteredEventSink(sink sdkModels.EventSink, filter sdkModels.EventSinkFilter) sdkModels.EventSink {
+	return &filteredEventSink{EventSink: sink, filter: filter}
+}
+
+func (s *filteredEventSink) Send(event dtos.Event, correlationID string) error {
+	if !s.filter.Match(event) {
+		return nil
+	}
+	return s.EventSink.Send(event, correlationID)
+}
+
+// sinkQueueSize is the number of events waiting to be sent to an additional EventSink, beyond
+// which the events are dropped rather than holding up the publishing.
+const sinkQueueSize = 100
+
+// publishEventSink is the built-in EventSink publishing the events passing its filter to the
+// MessageBus or core-data, with the QoS, batching and store and forward of the device service.
+type publishEventSink struct {
+	ctx    context.Context
+	filter sdkModels.EventSinkFilter
+	dic    *di.Container
+}
+
+// NewPublishEventSink returns the built-in EventSink publishing the events passing the filter
+// to the MessageBus or core-data, the retries of publishing being given up once ctx is done.
+func NewPublishEventSink(ctx context.Context, filter sdkModels.EventSinkFilter, dic *di.Container) sdkModels.EventSink {
+	return &publishEventSink{ctx: ctx, filter: filter, dic: dic}
+}
+
+func (s *publishEventSink) Name() string {
+	if container.ConfigurationFrom(s.dic.Get).Device.UseMessageBus {
+		return "MessageBus"
+	}
+	return "core-data"
+}
+
+func (s *publishEventSink) Send(event dtos.Event, correlationID string) error {
+	s.send(&event, correlationID, nil)
+	return nil
+}
+
+// send publishes the event, or reports ErrEventFiltered to outcome if it does not pass the filter.
+func (s *publishEventSink) send(event *dtos.Event, correlationID string, outcome func(error)) {
+	if !s.filter.Match(*event) {
+		reportOutcome(outcome, sdkModels.ErrEventFiltered)
+		return
+	}
+	publish(s.ctx, event, correlationID, s.dic, outcome)
+}
+
+type queuedEvent struct {
+	event         dtos.Event
+	correlationID string
+}
+
+// queuedEventSink sends the events to an additional EventSink from its own goroutine, so
+// that a slow sink holds up neither the publishing nor the other sinks.
+type queuedEventSink struct {
+	sdkModels.EventSink
+	queue chan queuedEvent
+}
+
+func (s *queuedEventSink) Send(event dtos.Event, correlationID string) error {
+	select {
+	case s.queue <- queuedEvent{event: event, correlationID: correlationID}:
+		return nil
+	default:
+		return fmt.Errorf("queue of %d events full", sinkQueueSize)
+	}
+}
+
+// StartEventSinks registers the sinks of the events, the built-in one publishing the events
+// passing publishFilter first, followed by the additional sinks. The events are sent to the
+// additional sinks in the background until ctx is done.
+func StartEventSinks(ctx context.Context, wg *sync.WaitGroup, publishFilter sdkModels.EventSinkFilter, sinks []sdkModels.EventSink, dic *di.Container) {
+	registerEventSinks([]sdkModels.EventSink{NewPublishEventSink(ctx, publishFilter, dic)}, dic)
+	for _, sink := range sinks {
+		AddEventSink(ctx, wg, sink, dic)
+	}
+}
+
+// AddEventSink registers an additional sink after the ones registered by StartEventSinks.
+// The events are sent to it in the background until ctx is done.
+func AddEventSink(ctx context.Context, wg *sync.WaitGroup, sink sdkModels.EventSink, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	queued := &queuedEventSink{EventSink: sink, queue: make(chan queuedEvent, sinkQueueSize)}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-queued.queue:
+				if err := queued.EventSink.Send(e.event, e.correlationID); err != nil {
+					failedSinkEvents.Add(1)
+					lc.Errorf("Failed to send event(deviceName: %s, sourceName: %s, id: %s) to sink %s: %v", e.event.DeviceName, e.event.SourceName, e.event.Id, queued.Name(), err)
+				}
+			}
+		}
+	}()
+
+	registerEventSinks(append(container.EventSinksFrom(dic.Get), queued), dic)
+}
+
+// SetPublishFilter replaces the filter of the built-in sink registered by StartEventSinks.
+func SetPublishFilter(ctx context.Context, publishFilter sdkModels.EventSinkFilter, dic *di.Container) {
+	sinks := append([]sdkModels.EventSink(nil), container.EventSinksFrom(dic.Get)...)
+	for i, sink := range sinks {
+		if _, ok := sink.(*publishEventSink); ok {
+			sinks[i] = NewPublishEventSink(ctx, publishFilter, dic)
+		}
+	}
+	registerEventSinks(sinks, dic)
+}
+
+// registerEventSinks registers sinks, which are never modified afterwards so that the events
+// being sent keep the sinks registered before.
+func registerEventSinks(sinks []sdkModels.EventSink, dic *di.Container) {
+	registered := sinks[:len(sinks):len(sinks)]
+	dic.Update(di.ServiceConstructorMap{
+		container.EventSinksName: func(get di.Get) interface{} {
+			return registered
+		},
+	})
+}
+
+// sendToSinks sends the event to the sinks registered by StartEventSinks, in their
+// registration order, and reports the outcome of publishing it. A sink failing does not
+// prevent the others to receive it. The event is only published if no sink is registered.
+func sendToSinks(event *dtos.Event, correlationID string, dic *di.Container, outcome func(error)) {
+	sinks := container.EventSinksFrom(dic.Get)
+	if len(sinks) == 0 {
+		publish(context.Background(), event, correlationID, dic, outcome)
+		return
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	for _, sink := range sinks {
+		if publisher, ok := sink.(*publishEventSink); ok {
+			publisher.send(event, correlationID, outcome)
+			continue
+		}
+		if err := sink.Send(*event, correlationID); err != nil {
+			failedSinkEvents.Add(1)
+			lc.Errorf("Failed to send event(deviceName: %s, sourceName: %s, id: %s) to sink %s: %v", event.DeviceName, event.SourceName, event.Id, sink.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func TestSendEvent_EventSinks(t *testing.T) {
+	filtered := &mocks.EventSink{}
+	filtered.On("Name").Return("filtered")
+	var filteredCalls, failingCalls int32
+	filtered.On("Send", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) { atomic.AddInt32(&filteredCalls, 1) })
+	failing := &mocks.EventSink{}
+	failing.On("Name").Return("failing")
+	failing.On("Send", mock.Anything, mock.Anything).Return(errors.New("sink unavailable")).Run(func(mock.Arguments) { atomic.AddInt32(&failingCalls, 1) })
+	release := make(chan struct{})
+	slow := &mocks.EventSink{}
+	slow.On("Name").Return("slow")
+	slow.On("Send", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) { <-release })
+
+	mc := &messageClient{published: make(chan publishedEnvelope, 10)}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Device: config.DeviceInfo{UseMessageBus: true}}
+		},
+		container.MessagingClientName: func(get di.Get) interface{} {
+			return mc
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		close(release)
+		cancel()
+		wg.Wait()
+	}()
+	StartEventSinks(ctx, wg, sdkModels.EventSinkFilter{DeviceNames: []string{"test-device"}}, []sdkModels.EventSink{
+		NewFilteredEventSink(slow, sdkModels.EventSinkFilter{}),
+		NewFilteredEventSink(failing, sdkModels.EventSinkFilter{}),
+		NewFilteredEventSink(filtered, sdkModels.EventSinkFilter{DeviceNames: []string{"test-device"}, SourceNames: []string{"test-resource"}}),
+	}, dic)
+
+	// the events are published without waiting for the additional sinks, the built-in sink
+	// publishing only the events passing its filter
+	var outcomes []error
+	outcome := func(err error) { outcomes = append(outcomes, err) }
+	SendEventWithOutcome(testEvent("1", "test-device"), "correlation-1", dic, outcome)
+	SendEventWithOutcome(testEvent("2", "other-device"), "correlation-2", dic, outcome)
+	assert.Len(t, mc.published, 1)
+	assert.Equal(t, []error{nil, sdkModels.ErrEventFiltered}, outcomes)
+
+	// a failing or slow sink prevents neither the other sinks to receive the events
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&failingCalls) == 2 && atomic.LoadInt32(&filteredCalls) == 1
+	}, time.Second, 10*time.Millisecond)
+	filtered.AssertCalled(t, "Send", *testEvent("1", "test-device"), "correlation-1")
+}
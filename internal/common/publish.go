@@ -0,0 +1,141 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+)
+
+var (
+	publishedEvents = telemetry.NewMetric("EventsPublished")
+	failedEvents    = telemetry.NewMetric("EventsPublishFailed")
+	retriedEvents   = telemetry.NewMetric("EventsPublishRetried")
+)
+
+// ValidateEventPublish checks the QoS and the retry delays of Device.EventPublish.
+func ValidateEventPublish(dic *di.Container) errors.EdgeX {
+	info := container.ConfigurationFrom(dic.Get).Device.EventPublish
+	switch info.QoS {
+	case "", QoSFireAndForget, QoSAtLeastOnce:
+	default:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.EventPublish.QoS %s", info.QoS), nil)
+	}
+	baseDelay, _, err := publishDelays(info)
+	if err != nil {
+		return err
+	}
+	if info.QoS == QoSAtLeastOnce && baseDelay <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Device.EventPublish.BaseDelay must be positive with the AtLeastOnce QoS", nil)
+	}
+	return nil
+}
+
+func publishDelays(info config.EventPublishInfo) (baseDelay time.Duration, maxDelay time.Duration, edgexErr errors.EdgeX) {
+	var err error
+	if info.BaseDelay != "" {
+		baseDelay, err = time.ParseDuration(info.BaseDelay)
+		if err != nil {
+			return 0, 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.EventPublish.BaseDelay %s", info.BaseDelay), err)
+		}
+	}
+	if info.MaxDelay != "" {
+		maxDelay, err = time.ParseDuration(info.MaxDelay)
+		if err != nil {
+			return 0, 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.EventPublish.MaxDelay %s", info.MaxDelay), err)
+		}
+	}
+	return baseDelay, maxDelay, nil
+}
+
+// publishWithQoS runs publish, which publishes count events at once, once with FireAndForget
+// or until it succeeds or uses up Device.EventPublish.MaxAttempts with AtLeastOnce, and
+// returns the error of the last attempt. A permanent error is not retried, and neither once
+// ctx is done.
+func publishWithQoS(ctx context.Context, count int, dic *di.Container, publish func() error) error {
+	info := container.ConfigurationFrom(dic.Get).Device.EventPublish
+	attempts := 1
+	if info.QoS == QoSAtLeastOnce && info.MaxAttempts > 1 {
+		attempts = info.MaxAttempts
+	}
+	// the delays are validated at startup
+	delay, maxDelay, _ := publishDelays(info)
+
+	for attempt := 1; ; attempt++ {
+		err := publish()
+		if err == nil {
+			publishedEvents.Add(int64(count))
+			return nil
+		}
+		if attempt >= attempts || isPermanent(err) {
+			failedEvents.Add(int64(count))
+			return err
+		}
+
+		retriedEvents.Add(int64(count))
+		bootstrapContainer.LoggingClientFrom(dic.Get).Debugf("attempt %d to publish %d events failed, retrying in %s: %v", attempt, count, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			failedEvents.Add(int64(count))
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+		if maxDelay > 0 && delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// permanentError is an error of publishing events which fails again on every attempt, such as
+// the events failed to be encoded.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// isPermanent reports whether publishing failed for good, either with a permanentError or
+// with the events rejected by core-data, rather than for a transient cause.
+func isPermanent(err error) bool {
+	if stdErrors.As(err, &permanentError{}) {
+		return true
+	}
+	var edgexErr errors.EdgeX
+	if stdErrors.As(err, &edgexErr) {
+		code := edgexErr.Code()
+		return code >= http.StatusBadRequest && code < http.StatusInternalServerError &&
+			code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+	}
+	return false
+}
+
+// reportOutcome calls the outcome callback of an event, if any.
+func reportOutcome(outcome func(error), err error) {
+	if outcome != nil {
+		outcome(err)
+	}
+}
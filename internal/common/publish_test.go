@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/eventstore"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// flakyEventClient returns an EventClient mock failing the first failures pushes, and the
+// count of pushes attempted.
+func flakyEventClient(failures int) (*clientMocks.EventClient, *int) {
+	attempts := 0
+	ec := &clientMocks.EventClient{}
+	ec.On("Add", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ requests.AddEventRequest) dtoCommon.BaseWithIdResponse {
+			return dtoCommon.BaseWithIdResponse{}
+		},
+		func(_ context.Context, _ requests.AddEventRequest) errors.EdgeX {
+			attempts++
+			if attempts <= failures {
+				return errors.NewCommonEdgeX(errors.KindServiceUnavailable, "core-data unreachable", nil)
+			}
+			return nil
+		})
+	return ec, &attempts
+}
+
+func TestSendEventWithOutcome_QoS(t *testing.T) {
+	tests := []struct {
+		name             string
+		info             config.EventPublishInfo
+		failures         int
+		storeAndForward  bool
+		expectedAttempts int
+		expectedFailed   bool
+		expectedStored   bool
+		expectedRetried  int64
+	}{
+		{"FireAndForget published", config.EventPublishInfo{QoS: QoSFireAndForget}, 0, false, 1, false, false, 0},
+		{"FireAndForget failed", config.EventPublishInfo{QoS: QoSFireAndForget, MaxAttempts: 3}, 1, false, 1, true, false, 0},
+		{"AtLeastOnce retried", config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 3, BaseDelay: "1ms"}, 2, false, 3, false, false, 2},
+		{"AtLeastOnce failed", config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 2, BaseDelay: "1ms"}, 2, false, 2, true, false, 1},
+		{"AtLeastOnce stored", config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 2, BaseDelay: "1ms"}, 2, true, 2, true, true, 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ec, attempts := flakyEventClient(tt.failures)
+			store, err := eventstore.NewFileStore(t.TempDir())
+			assert.NoError(t, err)
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{EventPublish: tt.info}}
+				},
+				bootstrapContainer.EventClientName: func(get di.Get) interface{} {
+					return ec
+				},
+			})
+			if tt.storeAndForward {
+				dic.Update(di.ServiceConstructorMap{
+					container.EventStoreName: func(get di.Get) interface{} {
+						return store
+					},
+				})
+			}
+			retried := retriedEvents.Value()
+
+			var outcomes []error
+			SendEventWithOutcome(testEvent("1", "test-device"), "", dic, func(err error) {
+				outcomes = append(outcomes, err)
+			})
+
+			assert.Equal(t, tt.expectedAttempts, *attempts)
+			assert.Equal(t, tt.expectedRetried, retriedEvents.Value()-retried)
+			if assert.Len(t, outcomes, 1) {
+				assert.Equal(t, tt.expectedFailed, outcomes[0] != nil)
+				assert.Equal(t, tt.expectedStored, outcomes[0] == sdkModels.ErrEventStored)
+			}
+		})
+	}
+}
+
+func TestPublishWithQoS_Abort(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Device: config.DeviceInfo{EventPublish: config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 3, BaseDelay: "1h"}}}
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		err    error
+		cancel bool
+	}{
+		{"permanent error", context.Background(), permanentError{stdErrors.New("failed to encode event")}, false},
+		{"rejected by core-data", context.Background(), errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid event", nil), false},
+		{"service stopped", ctx, errors.NewCommonEdgeX(errors.KindServiceUnavailable, "core-data unreachable", nil), true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			if tt.cancel {
+				cancel()
+			}
+			err := publishWithQoS(tt.ctx, 1, dic, func() error {
+				attempts++
+				return tt.err
+			})
+			// none of these errors waits for the delay of 1h before a retry
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, 1, attempts)
+		})
+	}
+}
+
+func TestValidateEventPublish(t *testing.T) {
+	tests := []struct {
+		name          string
+		info          config.EventPublishInfo
+		expectedError bool
+	}{
+		{"valid - default", config.EventPublishInfo{}, false},
+		{"valid - AtLeastOnce", config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 3, BaseDelay: "100ms", MaxDelay: "1s"}, false},
+		{"invalid - QoS", config.EventPublishInfo{QoS: "ExactlyOnce"}, true},
+		{"invalid - BaseDelay", config.EventPublishInfo{QoS: QoSAtLeastOnce, BaseDelay: "soon"}, true},
+		{"invalid - AtLeastOnce without BaseDelay", config.EventPublishInfo{QoS: QoSAtLeastOnce, MaxAttempts: 3}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{EventPublish: tt.info}}
+				},
+			})
+			err := ValidateEventPublish(dic)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
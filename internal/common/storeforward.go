@@ -0,0 +1,233 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// replayBatchSize is the number of stored events read from the EventStore at once during a replay.
+const replayBatchSize = 100
+
+var forwarder = &eventForwarder{}
+
+var (
+	storedEventsDepth = telemetry.NewMetric("EventStoreDepth")
+	droppedEvents     = telemetry.NewMetric("EventStoreDropped")
+	replayedEvents    = telemetry.NewMetric("EventStoreReplayed")
+)
+
+// eventForwarder stores the events failed to be published and replays them in order. The
+// mutex serializes the EventStore operations and the events published directly, but is not
+// held while the replayed events are published, the events sent meanwhile being stored. The oldest events dropped meanwhile to bound the queue are counted in trimmed,
+// so that the replay does not remove the events stored after them.
+type eventForwarder struct {
+	mutex   sync.Mutex
+	trimmed int
+}
+
+// StartEventForwarder replays the stored events every Device.StoreAndForward.ReplayInterval
+// until ctx is done.
+func StartEventForwarder(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	info := container.ConfigurationFrom(dic.Get).Device.StoreAndForward
+	store := container.EventStoreFrom(dic.Get)
+	if store == nil {
+		return nil
+	}
+
+	interval, err := time.ParseDuration(info.ReplayInterval)
+	if err != nil || interval <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.StoreAndForward.ReplayInterval %s", info.ReplayInterval), err)
+	}
+	if info.MaxAge != "" {
+		if _, err = time.ParseDuration(info.MaxAge); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.StoreAndForward.MaxAge %s", info.MaxAge), err)
+		}
+	}
+	depth, err := store.Len()
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to open the event store", err)
+	}
+	storedEventsDepth.Set(int64(depth))
+	lc.Infof("Store and forward of events enabled, %d stored events to replay", depth)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// the store may be replaced by DeviceService.SetEventStore meanwhile
+				forwarder.replay(container.EventStoreFrom(dic.Get), dic)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// send publishes the event unless stored events are waiting for replay, in which case it
+// is stored behind them so that the events are published in order.
+func (f *eventForwarder) send(ctx context.Context, event *dtos.Event, correlationID string, store sdkModels.EventStore, dic *di.Container, outcome func(error)) {
+	// the mutex is held until the event is published or stored, so that an event sent
+	// meanwhile does not overtake it
+	f.mutex.Lock()
+	pending, err := f.forward(ctx, event, correlationID, store, dic, outcome)
+	f.mutex.Unlock()
+	if !pending {
+		reportOutcome(outcome, err)
+	}
+}
+
+// forward publishes or stores the event, returning whether its outcome is pending or the
+// error reporting it was stored or dropped. The mutex must be held.
+func (f *eventForwarder) forward(ctx context.Context, event *dtos.Event, correlationID string, store sdkModels.EventStore, dic *di.Container, outcome func(error)) (bool, error) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	depth, err := store.Len()
+	if err == nil && depth == 0 {
+		var pending bool
+		pending, err = dispatchEvent(ctx, event, correlationID, dic, outcome)
+		if err == nil {
+			return pending, nil
+		}
+		if isPermanent(err) {
+			droppedEvents.Add(1)
+			lc.Errorf("Dropped event(deviceName: %s, sourceName: %s, id: %s): %v", event.DeviceName, event.SourceName, event.Id, err)
+			return false, err
+		}
+		lc.Warnf("%v; storing the event for replay", err)
+	}
+
+	err = f.store(sdkModels.StoredEvent{Event: *event, CorrelationID: correlationID, Stored: time.Now().UnixNano()}, store, dic)
+	if err == nil {
+		err = sdkModels.ErrEventStored
+	}
+	return false, err
+}
+
+// store appends the event to the EventStore, dropping the oldest events beyond
+// Device.StoreAndForward.MaxEvents.
+func (f *eventForwarder) store(event sdkModels.StoredEvent, store sdkModels.EventStore, dic *di.Container) error {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	maxEvents := container.ConfigurationFrom(dic.Get).Device.StoreAndForward.MaxEvents
+	defer f.updateDepth(store)
+
+	if maxEvents > 0 {
+		depth, err := store.Len()
+		if err == nil && depth >= maxEvents {
+			if err = store.Remove(depth - maxEvents + 1); err == nil {
+				f.trimmed += depth - maxEvents + 1
+				droppedEvents.Add(int64(depth - maxEvents + 1))
+				lc.Warnf("Event store is full (%d), dropped the oldest events", maxEvents)
+			}
+		}
+	}
+
+	err := store.Append(event)
+	if err != nil {
+		droppedEvents.Add(1)
+		lc.Errorf("Failed to store event(deviceName: %s, sourceName: %s, id: %s): %v", event.Event.DeviceName, event.Event.SourceName, event.Event.Id, err)
+	}
+	return err
+}
+
+// reportStored reports the outcome of an event stored for replay, or failed to be stored.
+func reportStored(outcome func(error), err error) {
+	if err == nil {
+		err = sdkModels.ErrEventStored
+	}
+	reportOutcome(outcome, err)
+}
+
+// replay publishes the stored events in order, until the EventStore is empty or an event
+// fails to be published again. The events older than Device.StoreAndForward.MaxAge, or
+// failed to be published for good, are dropped.
+func (f *eventForwarder) replay(store sdkModels.EventStore, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	maxAge, _ := time.ParseDuration(container.ConfigurationFrom(dic.Get).Device.StoreAndForward.MaxAge)
+	defer f.updateDepth(store)
+
+	for {
+		f.mutex.Lock()
+		events, err := store.Peek(replayBatchSize)
+		f.trimmed = 0
+		f.mutex.Unlock()
+		if err != nil {
+			lc.Errorf("Failed to read the event store: %v", err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		var done, dropped int
+		var publishErr error
+		for _, stored := range events {
+			if maxAge > 0 && time.Since(time.Unix(0, stored.Stored)) > maxAge {
+				dropped++
+				done++
+				continue
+			}
+			event := stored.Event
+			if publishErr = publishEvent(&event, stored.CorrelationID, dic); publishErr != nil {
+				if !isPermanent(publishErr) {
+					break
+				}
+				lc.Errorf("Dropped stored event(deviceName: %s, sourceName: %s, id: %s): %v", event.DeviceName, event.SourceName, event.Id, publishErr)
+				publishErr = nil
+				dropped++
+			}
+			done++
+		}
+
+		// the events trimmed from the store while publishing were among the ones replayed
+		f.mutex.Lock()
+		remove := done - f.trimmed
+		if remove > 0 {
+			err = store.Remove(remove)
+		}
+		f.mutex.Unlock()
+		if err != nil {
+			lc.Errorf("Failed to remove replayed events from the event store: %v", err)
+			return
+		}
+		droppedEvents.Add(int64(dropped))
+		replayedEvents.Add(int64(done - dropped))
+		publishedEvents.Add(int64(done - dropped))
+		if done-dropped > 0 {
+			lc.Debugf("Replayed %d stored events", done-dropped)
+		}
+		if publishErr != nil {
+			lc.Debugf("Replay of stored events paused: %v", publishErr)
+			return
+		}
+	}
+}
+
+func (f *eventForwarder) updateDepth(store sdkModels.EventStore) {
+	if depth, err := store.Len(); err == nil {
+		storedEventsDepth.Set(int64(depth))
+	}
+}
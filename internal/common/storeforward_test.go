@@ -0,0 +1,230 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/eventstore"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// eventClient is an EventClient mock recording the ids of the events pushed to core-data,
+// which is unreachable while down is set and rejects the events whose id is in rejected.
+// pushing is called, if set, before each event is pushed.
+type eventClient struct {
+	*clientMocks.EventClient
+	down     bool
+	rejected map[string]bool
+	pushing  func(id string)
+	pushed   []string
+}
+
+func newEventClient() *eventClient {
+	ec := &eventClient{EventClient: &clientMocks.EventClient{}}
+	ec.On("Add", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req requests.AddEventRequest) dtoCommon.BaseWithIdResponse {
+			return dtoCommon.BaseWithIdResponse{}
+		},
+		func(_ context.Context, req requests.AddEventRequest) errors.EdgeX {
+			if ec.pushing != nil {
+				ec.pushing(req.Event.Id)
+			}
+			if ec.down {
+				return errors.NewCommonEdgeX(errors.KindServiceUnavailable, "core-data unreachable", nil)
+			}
+			if ec.rejected[req.Event.Id] {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid event", nil)
+			}
+			ec.pushed = append(ec.pushed, req.Event.Id)
+			return nil
+		})
+	return ec
+}
+
+func storeForwardDic(t *testing.T, ec *eventClient, info config.StoreAndForwardInfo) (*di.Container, sdkModels.EventStore) {
+	store, err := eventstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Device: config.DeviceInfo{StoreAndForward: info}}
+		},
+		bootstrapContainer.EventClientName: func(get di.Get) interface{} {
+			return ec
+		},
+		container.EventStoreName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+	return dic, store
+}
+
+func storedIds(t *testing.T, store sdkModels.EventStore) []string {
+	events, err := store.Peek(replayBatchSize)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.Event.Id)
+	}
+	return ids
+}
+
+func TestSendEvent_StoreAndForward(t *testing.T) {
+	ec := newEventClient()
+	dic, store := storeForwardDic(t, ec, config.StoreAndForwardInfo{Enabled: true})
+	dropped := droppedEvents.Value()
+	replayed := replayedEvents.Value()
+
+	SendEvent(&dtos.Event{Id: "1"}, "", dic)
+	assert.Equal(t, []string{"1"}, ec.pushed)
+	assert.Empty(t, storedIds(t, store))
+
+	// failed events are stored, and the following ones are stored behind them
+	ec.down = true
+	SendEvent(&dtos.Event{Id: "2"}, "", dic)
+	ec.down = false
+	SendEvent(&dtos.Event{Id: "3"}, "", dic)
+	assert.Equal(t, []string{"1"}, ec.pushed)
+	assert.Equal(t, []string{"2", "3"}, storedIds(t, store))
+	assert.Equal(t, int64(2), storedEventsDepth.Value())
+
+	ec.down = true
+	forwarder.replay(store, dic)
+	assert.Equal(t, []string{"2", "3"}, storedIds(t, store))
+
+	ec.down = false
+	forwarder.replay(store, dic)
+	assert.Equal(t, []string{"1", "2", "3"}, ec.pushed)
+	assert.Empty(t, storedIds(t, store))
+	assert.Equal(t, int64(0), storedEventsDepth.Value())
+	assert.Equal(t, replayed+2, replayedEvents.Value())
+	assert.Equal(t, dropped, droppedEvents.Value())
+}
+
+func TestSendEvent_StoreAndForwardOrder(t *testing.T) {
+	ec := newEventClient()
+	dic, store := storeForwardDic(t, ec, config.StoreAndForwardInfo{Enabled: true})
+
+	// the event sent while another is published waits for it, and is stored behind it
+	// rather than published first once it fails
+	sent := make(chan struct{})
+	ec.pushing = func(id string) {
+		if id != "1" {
+			return
+		}
+		go func() {
+			defer close(sent)
+			SendEvent(&dtos.Event{Id: "2"}, "", dic)
+		}()
+		select {
+		case <-sent:
+		case <-time.After(100 * time.Millisecond):
+		}
+		ec.down = true
+	}
+	SendEvent(&dtos.Event{Id: "1"}, "", dic)
+	<-sent
+	assert.Empty(t, ec.pushed)
+	assert.Equal(t, []string{"1", "2"}, storedIds(t, store))
+
+	ec.pushing = nil
+	ec.down = false
+	forwarder.replay(store, dic)
+	assert.Equal(t, []string{"1", "2"}, ec.pushed)
+}
+
+func TestSendEvent_StoreAndForwardBounds(t *testing.T) {
+	ec := newEventClient()
+	ec.down = true
+	dic, store := storeForwardDic(t, ec, config.StoreAndForwardInfo{Enabled: true, MaxEvents: 2, MaxAge: "1h"})
+	dropped := droppedEvents.Value()
+
+	for _, id := range []string{"1", "2", "3"} {
+		SendEvent(&dtos.Event{Id: id}, "", dic)
+	}
+	assert.Equal(t, []string{"2", "3"}, storedIds(t, store))
+	assert.Equal(t, dropped+1, droppedEvents.Value())
+
+	// events older than MaxAge are dropped instead of being replayed
+	require.NoError(t, store.Remove(2))
+	require.NoError(t, store.Append(sdkModels.StoredEvent{Event: dtos.Event{Id: "expired"}, Stored: time.Now().Add(-2 * time.Hour).UnixNano()}))
+	require.NoError(t, store.Append(sdkModels.StoredEvent{Event: dtos.Event{Id: "4"}, Stored: time.Now().UnixNano()}))
+	ec.down = false
+	forwarder.replay(store, dic)
+	assert.Equal(t, []string{"4"}, ec.pushed)
+	assert.Equal(t, dropped+2, droppedEvents.Value())
+}
+
+func TestSendEvent_StoreAndForwardPermanentFailure(t *testing.T) {
+	ec := newEventClient()
+	ec.rejected = map[string]bool{"1": true, "3": true}
+	dic, store := storeForwardDic(t, ec, config.StoreAndForwardInfo{Enabled: true})
+	dropped := droppedEvents.Value()
+
+	// an event rejected by core-data is dropped rather than blocking the following ones
+	var outcome error
+	SendEventWithOutcome(&dtos.Event{Id: "1"}, "", dic, func(err error) { outcome = err })
+	assert.Error(t, outcome)
+	assert.NotErrorIs(t, outcome, sdkModels.ErrEventStored)
+	assert.Empty(t, storedIds(t, store))
+	assert.Equal(t, dropped+1, droppedEvents.Value())
+
+	// a stored event rejected on replay is dropped, and the replay goes on
+	for _, id := range []string{"2", "3", "4"} {
+		require.NoError(t, store.Append(sdkModels.StoredEvent{Event: dtos.Event{Id: id}, Stored: time.Now().UnixNano()}))
+	}
+	forwarder.replay(store, dic)
+	assert.Equal(t, []string{"2", "4"}, ec.pushed)
+	assert.Empty(t, storedIds(t, store))
+	assert.Equal(t, dropped+2, droppedEvents.Value())
+}
+
+func TestSendEvent_StoreAndForwardReplayUnlocked(t *testing.T) {
+	ec := newEventClient()
+	dic, store := storeForwardDic(t, ec, config.StoreAndForwardInfo{Enabled: true, MaxEvents: 3})
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, store.Append(sdkModels.StoredEvent{Event: dtos.Event{Id: id}, Stored: time.Now().UnixNano()}))
+	}
+
+	// the event sent while the replay publishes is stored behind the replayed ones, and the
+	// oldest event, being replayed, is trimmed from the full store
+	ec.pushing = func(id string) {
+		if id == "1" {
+			SendEvent(&dtos.Event{Id: "4"}, "", dic)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		forwarder.replay(store, dic)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "replay blocked the events sent meanwhile")
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ec.pushed)
+	assert.Empty(t, storedIds(t, store))
+}
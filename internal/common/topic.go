@@ -0,0 +1,242 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+// missingTopicValue replaces the placeholders of a topic template without value for an event,
+// e.g. {label:site} of a device without site label.
+const missingTopicValue = "unknown"
+
+// topicValueReplacer keeps the placeholder values within one topic level and free of wildcards.
+var topicValueReplacer = strings.NewReplacer("/", "_", "+", "_", "#", "_")
+
+// topicPlaceholder returns the value of a placeholder for an event. The device and profile
+// are nil when they are not in the cache.
+type topicPlaceholder func(prefix string, event *dtos.Event, device *models.Device, profile *models.DeviceProfile) string
+
+// topicSegment is either a literal part of a topic template or a placeholder.
+type topicSegment struct {
+	literal     string
+	placeholder topicPlaceholder
+	// needsCache indicates the placeholder is evaluated from the cached device or profile
+	needsCache bool
+	// verbatim indicates the value may span several topic levels
+	verbatim bool
+}
+
+// topicTemplate is a parsed Device.PublishTopicTemplate.
+type topicTemplate []topicSegment
+
+// topicTemplates caches the parsed templates by their source.
+var topicTemplates sync.Map
+
+// ValidatePublishTopicTemplate checks that Device.PublishTopicTemplate, if set, is a valid
+// topic template.
+func ValidatePublishTopicTemplate(dic *di.Container) errors.EdgeX {
+	template := container.ConfigurationFrom(dic.Get).Device.PublishTopicTemplate
+	if template == "" {
+		return nil
+	}
+	if _, err := topicTemplateFor(template); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.PublishTopicTemplate %s", template), err)
+	}
+	return nil
+}
+
+func topicTemplateFor(template string) (topicTemplate, error) {
+	if parsed, ok := topicTemplates.Load(template); ok {
+		return parsed.(topicTemplate), nil
+	}
+	parsed, err := parseTopicTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	topicTemplates.Store(template, parsed)
+	return parsed, nil
+}
+
+// parseTopicTemplate parses a template made of literal text and the placeholders:
+//
+//	{prefix}                      MessageQueue.PublishTopicPrefix
+//	{profile}, {device}, {source} the profile, device and source names of the event
+//	{service}                     the service name of the device
+//	{manufacturer}, {model}       the manufacturer and model of the device profile
+//	{label:<key>}                 the value of the device label "<key>=<value>"
+//	{protocol:<name>:<property>}  the value of a device protocol property
+//	{tag:<name>}                  the value of an event tag
+func parseTopicTemplate(template string) (topicTemplate, error) {
+	if strings.ContainsAny(template, "+#") {
+		return nil, fmt.Errorf("topic wildcards are not allowed")
+	}
+	var parsed topicTemplate
+	rest := template
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			parsed = append(parsed, topicSegment{literal: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("unexpected '}' at offset %d", len(template)-len(rest)+open)
+		}
+		if open > 0 {
+			parsed = append(parsed, topicSegment{literal: rest[:open]})
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] != '}' {
+			return nil, fmt.Errorf("unterminated placeholder at offset %d", len(template)-len(rest)+open)
+		}
+		segment, err := parseTopicPlaceholder(rest[open+1 : open+1+end])
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, segment)
+		rest = rest[open+1+end+1:]
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("empty topic template")
+	}
+	return parsed, nil
+}
+
+func parseTopicPlaceholder(placeholder string) (topicSegment, error) {
+	fields := strings.Split(placeholder, ":")
+	for _, field := range fields {
+		if field == "" {
+			return topicSegment{}, fmt.Errorf("invalid placeholder {%s}", placeholder)
+		}
+	}
+
+	switch {
+	case placeholder == "prefix":
+		return topicSegment{verbatim: true, placeholder: func(prefix string, _ *dtos.Event, _ *models.Device, _ *models.DeviceProfile) string {
+			return prefix
+		}}, nil
+	case placeholder == "profile":
+		return topicSegment{placeholder: func(_ string, event *dtos.Event, _ *models.Device, _ *models.DeviceProfile) string {
+			return event.ProfileName
+		}}, nil
+	case placeholder == "device":
+		return topicSegment{placeholder: func(_ string, event *dtos.Event, _ *models.Device, _ *models.DeviceProfile) string {
+			return event.DeviceName
+		}}, nil
+	case placeholder == "source":
+		return topicSegment{placeholder: func(_ string, event *dtos.Event, _ *models.Device, _ *models.DeviceProfile) string {
+			return event.SourceName
+		}}, nil
+	case placeholder == "service":
+		return topicSegment{needsCache: true, placeholder: func(_ string, _ *dtos.Event, device *models.Device, _ *models.DeviceProfile) string {
+			if device == nil {
+				return ""
+			}
+			return device.ServiceName
+		}}, nil
+	case placeholder == "manufacturer":
+		return topicSegment{needsCache: true, placeholder: func(_ string, _ *dtos.Event, _ *models.Device, profile *models.DeviceProfile) string {
+			if profile == nil {
+				return ""
+			}
+			return profile.Manufacturer
+		}}, nil
+	case placeholder == "model":
+		return topicSegment{needsCache: true, placeholder: func(_ string, _ *dtos.Event, _ *models.Device, profile *models.DeviceProfile) string {
+			if profile == nil {
+				return ""
+			}
+			return profile.Model
+		}}, nil
+	case fields[0] == "label" && len(fields) == 2:
+		key := fields[1] + "="
+		return topicSegment{needsCache: true, placeholder: func(_ string, _ *dtos.Event, device *models.Device, _ *models.DeviceProfile) string {
+			if device == nil {
+				return ""
+			}
+			for _, label := range device.Labels {
+				if strings.HasPrefix(label, key) {
+					return label[len(key):]
+				}
+			}
+			return ""
+		}}, nil
+	case fields[0] == "protocol" && len(fields) == 3:
+		protocol, property := fields[1], fields[2]
+		return topicSegment{needsCache: true, placeholder: func(_ string, _ *dtos.Event, device *models.Device, _ *models.DeviceProfile) string {
+			if device == nil {
+				return ""
+			}
+			return device.Protocols[protocol][property]
+		}}, nil
+	case fields[0] == "tag" && len(fields) == 2:
+		tag := fields[1]
+		return topicSegment{placeholder: func(_ string, event *dtos.Event, _ *models.Device, _ *models.DeviceProfile) string {
+			value, ok := event.Tags[tag]
+			if !ok || value == nil {
+				return ""
+			}
+			return fmt.Sprint(value)
+		}}, nil
+	}
+	return topicSegment{}, fmt.Errorf("unknown placeholder {%s}", placeholder)
+}
+
+// needsCache indicates whether the template refers to the cached device or profile.
+func (t topicTemplate) needsCache() bool {
+	for _, segment := range t {
+		if segment.needsCache {
+			return true
+		}
+	}
+	return false
+}
+
+// execute returns the topic of the event.
+func (t topicTemplate) execute(prefix string, event *dtos.Event, device *models.Device, profile *models.DeviceProfile) string {
+	var topic strings.Builder
+	for _, segment := range t {
+		if segment.placeholder == nil {
+			topic.WriteString(segment.literal)
+			continue
+		}
+		value := segment.placeholder(prefix, event, device, profile)
+		if value == "" {
+			value = missingTopicValue
+		} else if !segment.verbatim {
+			value = topicValueReplacer.Replace(value)
+		}
+		topic.WriteString(value)
+	}
+	return topic.String()
+}
+
+// templateTopic evaluates the topic template for the event, looking up its device and
+// profile in the cache when the template refers to them.
+func templateTopic(template topicTemplate, prefix string, event *dtos.Event) string {
+	var device *models.Device
+	var profile *models.DeviceProfile
+	if template.needsCache() {
+		if d, ok := cache.Devices().ForName(event.DeviceName); ok {
+			device = &d
+		}
+		if p, ok := cache.Profiles().ForName(event.ProfileName); ok {
+			profile = &p
+		}
+	}
+	return template.execute(prefix, event, device, profile)
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicTemplate(t *testing.T) {
+	event := &dtos.Event{
+		ProfileName: "test-profile",
+		DeviceName:  "test-device",
+		SourceName:  "test/resource",
+		Tags:        map[string]interface{}{"line": 7},
+	}
+	device := &models.Device{
+		Name:        "test-device",
+		ServiceName: "test-service",
+		Labels:      []string{"sensor", "site=plant-1"},
+		Protocols:   map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.1"}},
+	}
+	profile := &models.DeviceProfile{Name: "test-profile", Manufacturer: "IOTech", Model: "M+1"}
+
+	tests := []struct {
+		name     string
+		template string
+		device   *models.Device
+		expected string
+	}{
+		{"default levels", "{prefix}/{profile}/{device}/{source}", device, "edgex/events/device/test-profile/test-device/test_resource"},
+		{"label", "site/{label:site}/{device}/{source}", device, "site/plant-1/test-device/test_resource"},
+		{"protocol property", "{protocol:modbus-tcp:Address}/{device}", device, "10.0.0.1/test-device"},
+		{"tag", "line-{tag:line}", device, "line-7"},
+		{"service and profile", "{service}/{manufacturer}/{model}", device, "test-service/IOTech/M_1"},
+		{"missing label", "site/{label:area}/{device}", device, "site/unknown/test-device"},
+		{"missing tag", "{tag:zone}", device, "unknown"},
+		{"device not cached", "{label:site}/{service}", nil, "unknown/unknown"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := parseTopicTemplate(tt.template)
+			require.NoError(t, err)
+			topic := template.execute("edgex/events/device", event, tt.device, profile)
+			assert.Equal(t, tt.expected, topic)
+		})
+	}
+}
+
+func TestParseTopicTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"unknown placeholder", "{prefix}/{unknown}"},
+		{"unterminated placeholder", "{prefix}/{device"},
+		{"nested placeholder", "{prefix}/{label:{device}}"},
+		{"unexpected brace", "{prefix}/device}"},
+		{"empty placeholder", "{prefix}/{}"},
+		{"label without key", "{label:}"},
+		{"protocol without property", "{protocol:modbus-tcp}"},
+		{"wildcard", "{prefix}/+/{device}"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTopicTemplate(tt.template)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -10,8 +10,11 @@ package common
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"time"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -21,6 +24,8 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
 )
 
@@ -38,20 +43,88 @@ func UpdateLastConnected(name string, lc logger.LoggingClient, dc interfaces.Dev
 	}
 }
 
-func UpdateOperatingState(name string, state string, lc logger.LoggingClient, dc interfaces.DeviceClient) {
+// UpdateOperatingState updates the OperatingState of the device in core-metadata, and returns
+// the error, also logged, if core-metadata cannot be reached or rejects the update.
+func UpdateOperatingState(name string, state string, lc logger.LoggingClient, dc interfaces.DeviceClient) errors.EdgeX {
 	device := dtos.UpdateDevice{
 		Name:           &name,
 		OperatingState: &state,
 	}
 
 	req := requests.NewUpdateDeviceRequest(device)
-	_, err := dc.Update(context.Background(), []requests.UpdateDeviceRequest{req})
+	res, err := dc.Update(context.Background(), []requests.UpdateDeviceRequest{req})
+	if err == nil && len(res) > 0 && res[0].StatusCode >= http.StatusMultipleChoices {
+		err = errors.NewCommonEdgeX(errors.KindMapping(res[0].StatusCode), res[0].Message, nil)
+	}
 	if err != nil {
 		lc.Errorf("failed to update OperatingState for Device %s in Core Metadata: %v", name, err)
+		return err
 	}
+	return nil
 }
 
+// SendEvent publishes the event to the MessageBus or core-data, and then sends it to the
+// additional sinks registered by the device service. When Device.StoreAndForward is enabled,
+// an event failed to be published is stored and replayed later.
 func SendEvent(event *dtos.Event, correlationID string, dic *di.Container) {
+	SendEventWithOutcome(event, correlationID, dic, nil)
+}
+
+// SendEventWithOutcome is SendEvent calling outcome, if not nil, once the outcome of
+// publishing the event is known, see AsyncValues.OnPublished.
+func SendEventWithOutcome(event *dtos.Event, correlationID string, dic *di.Container, outcome func(error)) {
+	sendToSinks(event, correlationID, dic, outcome)
+}
+
+// publish publishes the event to the MessageBus or core-data, storing it for replay if it
+// fails and Device.StoreAndForward is enabled. The retries are given up once ctx is done.
+func publish(ctx context.Context, event *dtos.Event, correlationID string, dic *di.Container, outcome func(error)) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	store := container.EventStoreFrom(dic.Get)
+	if store != nil {
+		forwarder.send(ctx, event, correlationID, store, dic, outcome)
+		return
+	}
+
+	pending, err := dispatchEvent(ctx, event, correlationID, dic, outcome)
+	if err != nil {
+		lc.Error(err.Error())
+	}
+	if !pending {
+		reportOutcome(outcome, err)
+	}
+}
+
+// dispatchEvent publishes the event according to Device.EventPublish.QoS, through the event
+// batcher when it is enabled. The outcome of a batched event is pending until its batch is
+// published, otherwise it is left to the caller.
+func dispatchEvent(ctx context.Context, event *dtos.Event, correlationID string, dic *di.Container, outcome func(error)) (pending bool, err error) {
+	configuration := container.ConfigurationFrom(dic.Get)
+	if configuration.Device.UseMessageBus {
+		if batched, err := batcher.add(event, correlationID, eventTopic(event, configuration), outcome); batched {
+			return err == nil, err
+		}
+	}
+	return false, publishWithQoS(ctx, 1, dic, func() error {
+		return publishEvent(event, correlationID, dic)
+	})
+}
+
+// eventTopic returns the MessageBus topic the event is published to, from
+// Device.PublishTopicTemplate when it is set.
+func eventTopic(event *dtos.Event, configuration *config.ConfigurationStruct) string {
+	if configuration.Device.PublishTopicTemplate != "" {
+		// the template is validated at startup
+		if template, err := topicTemplateFor(configuration.Device.PublishTopicTemplate); err == nil {
+			return templateTopic(template, configuration.MessageQueue.PublishTopicPrefix, event)
+		}
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", configuration.MessageQueue.PublishTopicPrefix, event.ProfileName, event.DeviceName, event.SourceName)
+}
+
+// publishEvent publishes the event to the MessageBus if Device.UseMessageBus is set, or
+// pushes it to core-data otherwise.
+func publishEvent(event *dtos.Event, correlationID string, dic *di.Container) error {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	configuration := container.ConfigurationFrom(dic.Get)
 	ctx := context.WithValue(context.Background(), common.CorrelationHeader, correlationID) // nolint: staticcheck
@@ -61,23 +134,42 @@ func SendEvent(event *dtos.Event, correlationID string, dic *di.Container) {
 		mc := container.MessagingClientFrom(dic.Get)
 		bytes, encoding, err := req.Encode()
 		if err != nil {
-			lc.Error(err.Error())
+			return permanentError{fmt.Errorf("failed to encode event: %v", err)}
 		}
 		ctx = context.WithValue(ctx, common.ContentType, encoding) // nolint: staticcheck
 		envelope := types.NewMessageEnvelope(bytes, ctx)
-		publishTopic := fmt.Sprintf("%s/%s/%s/%s", configuration.MessageQueue.PublishTopicPrefix, event.ProfileName, event.DeviceName, event.SourceName)
+		publishTopic := eventTopic(event, configuration)
 		err = mc.Publish(envelope, publishTopic)
 		if err != nil {
-			lc.Errorf("Failed to publish event to MessageBus: %s", err)
+			return fmt.Errorf("failed to publish event to MessageBus: %v", err)
 		}
 		lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) published to MessageBus", event.ProfileName, event.DeviceName, event.SourceName, event.Id)
 	} else {
 		ec := bootstrapContainer.EventClientFrom(dic.Get)
 		_, err := ec.Add(ctx, req)
 		if err != nil {
-			lc.Errorf("Failed to push event to Coredata: %s", err)
-		} else {
-			lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) pushed to Coredata", event.ProfileName, event.DeviceName, event.SourceName, event.Id)
+			return fmt.Errorf("failed to push event to Coredata: %w", err)
+		}
+		lc.Debugf("Event(profileName: %s, deviceName: %s, sourceName: %s, id: %s) pushed to Coredata", event.ProfileName, event.DeviceName, event.SourceName, event.Id)
+	}
+	return nil
+}
+
+// ProtocolProperty returns the first non-empty value of the named property among the
+// device protocols, which are visited in name order so that the result is deterministic.
+func ProtocolProperty(device models.Device, name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	protocols := make([]string, 0, len(device.Protocols))
+	for protocol := range device.Protocols {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	for _, protocol := range protocols {
+		if v := device.Protocols[protocol][name]; v != "" {
+			return v, true
 		}
 	}
+	return "", false
 }
@@ -45,10 +45,100 @@ type DeviceInfo struct {
 	AsyncBufferSize int
 	// EnableAsyncReadings to determine whether the Device Service would deal with the asynchronous readings
 	EnableAsyncReadings bool
+	// AsyncOverflow is the policy applied when the channel of the asynchronous readings is full.
+	AsyncOverflow AsyncOverflowInfo
+	// AsyncOrdering keeps the asynchronous readings of each device in order.
+	AsyncOrdering AsyncOrderingInfo
 	// Labels are properties applied to the device service to help with searching
 	Labels []string
 	// UseMessageBus indicates whether or not the Event are published directly to the MessageBus
 	UseMessageBus bool
+	// CommandResponseTopicPrefix is the MessageBus topic prefix of the responses to the device
+	// commands received on MessageQueue.SubscribeTopic when MessageQueue.SubscribeEnabled is
+	// set. The request ID is appended to it to form the topic of each response.
+	CommandResponseTopicPrefix string
+	// CommandParallelism is the maximum number of device commands received from the MessageBus
+	// executed at the same time, values below 1 execute them one after another. The next
+	// requests wait on the MessageBus until a command completes.
+	CommandParallelism int
+	// PublishTopicTemplate is the template of the MessageBus topic each event is published to,
+	// e.g. "{prefix}/{label:site}/{device}/{source}". It defaults to
+	// MessageQueue.PublishTopicPrefix followed by the profile, device and source names.
+	PublishTopicTemplate string
+	// CommandTimeout is the default deadline of a single read or write command sent to
+	// the ProtocolDriver, represented as a duration string. An empty value means no deadline.
+	// It can be overridden per request by the ds-timeout query parameter.
+	CommandTimeout string
+	// Concurrency limits the commands concurrently sent to the ProtocolDriver per device.
+	Concurrency ConcurrencyInfo
+	// ReadBatching coalesces the concurrent reads of the same device into one driver call.
+	ReadBatching ReadBatchingInfo
+	// Retry is the policy applied to failed driver reads and writes.
+	Retry RetryInfo
+	// Health manages the OperatingState of devices from the outcome of driver commands.
+	Health HealthInfo
+	// Verify configures the read-back verification of SET commands.
+	Verify VerifyInfo
+	// BatchCommand configures the execution of batch command requests.
+	BatchCommand BatchCommandInfo
+	// EventPublish configures the delivery guarantees of the events published to core-data
+	// or the MessageBus.
+	EventPublish EventPublishInfo
+	// StoreAndForward keeps the events which failed to be published for later replay.
+	StoreAndForward StoreAndForwardInfo
+	// LocalEventSink keeps the last events of the device service in a local directory.
+	LocalEventSink LocalEventSinkInfo
+	// BinaryStream configures the transfer of the binary values streamed by the ProtocolDriver.
+	BinaryStream BinaryStreamInfo
+	// EventBatching publishes the events of the same MessageBus topic together.
+	EventBatching EventBatchingInfo
+	// AutoEventScheduling configures the scheduler shared by the AutoEvents of all devices.
+	AutoEventScheduling AutoEventSchedulingInfo
+}
+
+// AsyncOverflowInfo is a struct which contains configuration of the backpressure applied to
+// the ProtocolDriver when the channel of the asynchronous readings, sized by AsyncBufferSize,
+// is full.
+type AsyncOverflowInfo struct {
+	// Policy is Block, blocking the driver until there is room, DropNewest, dropping the
+	// incoming AsyncValues, DropOldest, dropping the oldest queued AsyncValues, or Sample,
+	// keeping one of every SampleRate incoming AsyncValues in place of the oldest queued one.
+	// Empty means Block.
+	Policy string
+	// SampleRate is the rate of the AsyncValues kept by the Sample policy.
+	SampleRate int
+}
+
+// AsyncOrderingInfo is a struct which contains configuration of the ordered processing of
+// the readings pushed asynchronously by the ProtocolDriver.
+type AsyncOrderingInfo struct {
+	// OrderBy is Device or DeviceSource to keep in order the AsyncValues of the same device,
+	// or of the same device and source. Empty disables ordering, the AsyncValues being
+	// processed concurrently up to AsyncBufferSize.
+	OrderBy string
+	// Workers is the number of AsyncValues processed in parallel, the AsyncValues of the
+	// same device, or device and source, being always processed by the same worker.
+	Workers int
+	// QueueSize is the number of AsyncValues waiting for each worker.
+	QueueSize int
+	// FullPolicy is Block, waiting for room in the queue of a worker, or Drop, dropping the
+	// AsyncValues, when the queue is full. Empty means Block.
+	FullPolicy string
+}
+
+// AutoEventSchedulingInfo is a struct which contains configuration of the scheduling of the
+// AutoEvents.
+type AutoEventSchedulingInfo struct {
+	// StartupJitter spreads the first readings of the AutoEvents with an interval over up to
+	// this duration, each device having its own phase derived from its name so that the
+	// devices are not read in lockstep after a restart. The ds-startupjitter device protocol
+	// property overrides it for a device. Empty means no jitter.
+	StartupJitter string
+	// MissedTicks is the policy applied to the fire times of an AutoEvent missed while its
+	// previous reading is in progress: Skip, dropping them, CatchUpOnce, reading once as soon
+	// as the previous reading completes, or CatchUpAll, reading once for every missed fire
+	// time. Empty means Skip.
+	MissedTicks string
 }
 
 // DiscoveryInfo is a struct which contains configuration of device auto discovery.
@@ -60,6 +150,181 @@ type DiscoveryInfo struct {
 	Interval string
 }
 
+// ConcurrencyInfo is a struct which contains configuration of the per-device command limiter.
+// Each setting can be overridden by the device protocol properties ds-concurrencykey,
+// ds-maxconcurrent and ds-maxqueuedepth.
+type ConcurrencyInfo struct {
+	// MaxConcurrent is the maximum number of read and write commands executed by the
+	// ProtocolDriver at the same time for the same device or key, 0 means unlimited.
+	MaxConcurrent int
+	// MaxQueueDepth is the maximum number of commands waiting for execution in FIFO
+	// order, beyond which commands are rejected with a queue full error. 0 means unlimited.
+	MaxQueueDepth int
+	// KeyProperty is the name of a device protocol property (e.g. the serial port) whose
+	// value groups devices that share a single limiter instead of limiting each device alone.
+	KeyProperty string
+}
+
+// ReadBatchingInfo is a struct which contains configuration of the read coalescing. Each
+// setting can be overridden by the device protocol properties ds-batchwindow and
+// ds-batchmaxrequests.
+type ReadBatchingInfo struct {
+	// Window is the duration string during which the reads of a device are gathered
+	// before the driver is called once with the merged requests. Empty disables batching.
+	Window string
+	// MaxRequests flushes the batch before the window elapses once it holds this number
+	// of distinct requests, 0 means no limit.
+	MaxRequests int
+}
+
+// RetryInfo is a struct which contains the retry policy of failed driver read and write
+// operations. Drivers can mark errors as permanent or transient with models.NewPermanentError
+// and models.NewTransientError to bypass RetryableKinds.
+type RetryInfo struct {
+	// MaxAttempts is the total number of attempts of a command, 0 or 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the duration string of the delay before the first retry, doubled for
+	// every subsequent retry.
+	BaseDelay string
+	// MaxDelay is the duration string capping the delay between retries, empty means no cap.
+	MaxDelay string
+	// Jitter is the fraction, between 0 and 1, by which each delay is randomly reduced.
+	Jitter float64
+	// RetryableKinds lists the EdgeX error kinds (e.g. Communication) of unclassified
+	// driver errors which are retried, empty means all of them. Only the EdgeX errors of the
+	// driver have a kind, the other errors are of the Unknown kind.
+	RetryableKinds []string
+	// Writes enables the retries of write operations, which may not be idempotent. A timed
+	// out write is never retried since it may still be applied by the device.
+	Writes bool
+}
+
+// HealthInfo is a struct which contains configuration of the device health tracker.
+type HealthInfo struct {
+	// FailureThreshold is the number of consecutive failed driver commands after which a
+	// device is marked as DOWN, 0 disables the health tracker.
+	FailureThreshold int
+	// ProbeInterval is the duration string of the interval at which a DOWN device is probed.
+	ProbeInterval string
+	// ProbeResource is the name of the device resource read to probe a DOWN device when the
+	// driver does not implement models.HealthChecker. It can be overridden by the device
+	// protocol property ds-healthresource. Without either of them, a DOWN device is not
+	// probed and stays DOWN until updated.
+	ProbeResource string
+}
+
+// VerifyInfo is a struct which contains configuration of the read-back verification of
+// SET commands, enabled per device resource by the ds-verify attribute or per request by
+// the ds-verify query parameter.
+type VerifyInfo struct {
+	// Tolerance is the maximum difference between a requested and a read back numeric value,
+	// which can be overridden by the ds-verifytolerance attribute of the device resource.
+	Tolerance float64
+	// Delay is the duration string to wait between the write and the read back, giving the
+	// actuator time to settle.
+	Delay string
+	// PublishEvent publishes an event of the read back values, tagged with ds-verify, when
+	// they differ from the requested ones.
+	PublishEvent bool
+}
+
+// BatchCommandInfo is a struct which contains configuration of the batch command endpoint,
+// which executes the device commands of many devices in one request.
+type BatchCommandInfo struct {
+	// MaxCommands is the maximum number of commands in one batch request, 0 means unlimited.
+	MaxCommands int
+	// Parallelism is the maximum number of commands of a batch request executed at the
+	// same time, values below 1 execute them one after another.
+	Parallelism int
+}
+
+// EventPublishInfo is a struct which contains configuration of the delivery of the events
+// to core-data or the MessageBus.
+type EventPublishInfo struct {
+	// QoS is either FireAndForget, publishing each event once, or AtLeastOnce, retrying the
+	// publishing of an event until it succeeds or MaxAttempts is reached. The events which
+	// can never be published, failed to be encoded or rejected by core-data, are not retried.
+	// Empty means FireAndForget.
+	QoS string
+	// MaxAttempts is the total number of attempts to publish an event with AtLeastOnce.
+	MaxAttempts int
+	// BaseDelay is the duration string of the delay before the first retry, doubled for
+	// every subsequent retry. It is required with AtLeastOnce.
+	BaseDelay string
+	// MaxDelay is the duration string capping the delay between retries, empty means no cap.
+	MaxDelay string
+}
+
+// StoreAndForwardInfo is a struct which contains configuration of the event store, which keeps
+// the events failed to be published to core-data or the MessageBus and replays them in order.
+// The events which can never be published, failed to be encoded or rejected by core-data, are
+// dropped rather than stored.
+type StoreAndForwardInfo struct {
+	// Enabled controls whether or not the failed events are stored for replay.
+	Enabled bool
+	// Path is the directory of the default file-backed event store.
+	Path string
+	// MaxEvents is the maximum number of stored events, beyond which the oldest ones are
+	// dropped. 0 means unlimited.
+	MaxEvents int
+	// MaxAge is the duration string after which a stored event is dropped instead of being
+	// replayed, empty means no limit.
+	MaxAge string
+	// ReplayInterval is the duration string of the interval at which the replay of the
+	// stored events is attempted.
+	ReplayInterval string
+}
+
+// LocalEventSinkInfo is a struct which contains configuration of the built-in event sink
+// keeping the last events of the device service in a local directory, in addition to
+// core-data or the MessageBus.
+type LocalEventSinkInfo struct {
+	// Enabled controls whether or not the events are kept locally.
+	Enabled bool
+	// Path is the directory the events are kept in.
+	Path string
+	// MaxEvents is the number of the last events kept, 0 means unlimited.
+	MaxEvents int
+}
+
+// BinaryStreamInfo is a struct which contains configuration of the transfer of the binary
+// values streamed by the ProtocolDriver with NewStreamCommandValue, which are referenced by
+// an Object reading instead of being embedded in a Binary reading.
+type BinaryStreamInfo struct {
+	// Mode is ObjectStore, writing each value to the object store, or Chunked, publishing
+	// each value in ChunkSize MessageBus messages. Empty means ObjectStore.
+	Mode string
+	// Path is the directory of the default local filesystem object store.
+	Path string
+	// ChunkSize is the size in bytes of the payload of each message with Chunked.
+	ChunkSize int
+	// ChunkTopicPrefix is the MessageBus topic prefix of the chunks, followed by the device,
+	// resource and transfer ID, and the index of each chunk.
+	ChunkTopicPrefix string
+	// MaxBytes limits the size of a streamed value, 0 means unlimited.
+	MaxBytes int64
+	// Retention is the duration string after which the objects of the default local
+	// filesystem object store are deleted. Empty means they are kept until deleted by their
+	// consumer, the store growing without limit otherwise.
+	Retention string
+}
+
+// EventBatchingInfo is a struct which contains configuration of the batching of the events
+// published to the MessageBus. A batch is published as a JSON array of AddEventRequest once
+// any of its limits is reached.
+type EventBatchingInfo struct {
+	// Window is the duration string of the maximum time an event waits in a batch before
+	// it is published. Empty disables batching.
+	Window string
+	// MaxEvents publishes a batch once it holds this number of events, 0 means no limit.
+	MaxEvents int
+	// MaxBytes publishes a batch once its encoded events reach this size, 0 means no limit.
+	MaxBytes int
+	// Compression is the compression applied to the batches, either gzip, zstd or one
+	// registered by the device service. Empty means no compression.
+	Compression string
+}
+
 // Telemetry provides metrics (on a given device service) to system management.
 type Telemetry struct {
 	Alloc,
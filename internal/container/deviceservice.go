@@ -22,6 +22,21 @@ var ProtocolDiscoveryName = di.TypeInstanceToName((*sdkModels.ProtocolDiscovery)
 // ProtocolDriverName contains the name of protocol driver implementation in the DIC.
 var ProtocolDriverName = di.TypeInstanceToName((*sdkModels.ProtocolDriver)(nil))
 
+// ContextualProtocolDriverName contains the name of contextual protocol driver implementation in the DIC.
+var ContextualProtocolDriverName = di.TypeInstanceToName((*sdkModels.ContextualProtocolDriver)(nil))
+
+// HealthCheckerName contains the name of device health checker implementation in the DIC.
+var HealthCheckerName = di.TypeInstanceToName((*sdkModels.HealthChecker)(nil))
+
+// EventStoreName contains the name of the store of the events failed to be published in the DIC.
+var EventStoreName = di.TypeInstanceToName((*sdkModels.EventStore)(nil))
+
+// ObjectStoreName contains the name of the store of the streamed binary values in the DIC.
+var ObjectStoreName = di.TypeInstanceToName((*sdkModels.ObjectStore)(nil))
+
+// EventSinksName contains the name of the additional sinks of the events in the DIC.
+var EventSinksName = "EventSinks"
+
 // ManagerName contains the name of autoevent manager implementation in the DIC
 var ManagerName = di.TypeInstanceToName((*sdkModels.AutoEventManager)(nil))
 
@@ -56,6 +71,54 @@ func ProtocolDriverFrom(get di.Get) sdkModels.ProtocolDriver {
 	return get(ProtocolDriverName).(sdkModels.ProtocolDriver)
 }
 
+// ContextualProtocolDriverFrom helper function queries the DIC and returns contextual protocol driver implementation.
+func ContextualProtocolDriverFrom(get di.Get) sdkModels.ContextualProtocolDriver {
+	casted, ok := get(ContextualProtocolDriverName).(sdkModels.ContextualProtocolDriver)
+	if ok {
+		return casted
+	}
+	return nil
+}
+
+// HealthCheckerFrom helper function queries the DIC and returns device health checker implementation.
+func HealthCheckerFrom(get di.Get) sdkModels.HealthChecker {
+	casted, ok := get(HealthCheckerName).(sdkModels.HealthChecker)
+	if ok {
+		return casted
+	}
+	return nil
+}
+
+// EventStoreFrom helper function queries the DIC and returns the store of the events failed
+// to be published, which is nil unless Device.StoreAndForward is enabled.
+func EventStoreFrom(get di.Get) sdkModels.EventStore {
+	casted, ok := get(EventStoreName).(sdkModels.EventStore)
+	if ok {
+		return casted
+	}
+	return nil
+}
+
+// ObjectStoreFrom helper function queries the DIC and returns the store of the streamed binary
+// values, which is nil unless Device.BinaryStream.Mode is ObjectStore.
+func ObjectStoreFrom(get di.Get) sdkModels.ObjectStore {
+	casted, ok := get(ObjectStoreName).(sdkModels.ObjectStore)
+	if ok {
+		return casted
+	}
+	return nil
+}
+
+// EventSinksFrom helper function queries the DIC and returns the additional sinks of the
+// events registered by the device service.
+func EventSinksFrom(get di.Get) []sdkModels.EventSink {
+	casted, ok := get(EventSinksName).([]sdkModels.EventSink)
+	if ok {
+		return casted
+	}
+	return nil
+}
+
 // ManagerFrom helper function queries the DIC and returns autoevent manager implementation
 func ManagerFrom(get di.Get) sdkModels.AutoEventManager {
 	return get(ManagerName).(sdkModels.AutoEventManager)
@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// AutoEventStatuses responds with the status of the AutoEvents of the device named in the
+// route, or of all the devices.
+func (c *RestController) AutoEventStatuses(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[common.Name]
+	route := sdkCommon.ApiAllAutoEventRoute
+	if name != "" {
+		route = sdkCommon.ApiAutoEventByDeviceNameRoute
+	}
+
+	controller, err := c.autoEventController()
+	if err != nil {
+		c.sendEdgexError(writer, request, err, route)
+		return
+	}
+	statuses, err := controller.AutoEventStatuses(name)
+	if err != nil {
+		c.sendEdgexError(writer, request, err, route)
+		return
+	}
+	res := sdkResponses.NewMultiAutoEventStatusResponse("", "", http.StatusOK, statuses)
+	c.sendResponse(writer, request, route, res, http.StatusOK)
+}
+
+func (c *RestController) PauseAutoEvent(writer http.ResponseWriter, request *http.Request) {
+	controller, err := c.autoEventController()
+	if err == nil {
+		vars := mux.Vars(request)
+		err = controller.PauseAutoEvent(vars[common.Name], vars[common.SourceName])
+	}
+	c.sendAutoEventResponse(writer, request, err, sdkCommon.ApiAutoEventPauseRoute)
+}
+
+func (c *RestController) ResumeAutoEvent(writer http.ResponseWriter, request *http.Request) {
+	controller, err := c.autoEventController()
+	if err == nil {
+		vars := mux.Vars(request)
+		err = controller.ResumeAutoEvent(vars[common.Name], vars[common.SourceName])
+	}
+	c.sendAutoEventResponse(writer, request, err, sdkCommon.ApiAutoEventResumeRoute)
+}
+
+// TriggerAutoEvent starts a reading of the AutoEvent, responding once it is started rather
+// than once the event is sent.
+func (c *RestController) TriggerAutoEvent(writer http.ResponseWriter, request *http.Request) {
+	controller, err := c.autoEventController()
+	if err == nil {
+		vars := mux.Vars(request)
+		err = controller.TriggerAutoEvent(vars[common.Name], vars[common.SourceName])
+	}
+	if err == nil {
+		res := commonDTO.NewBaseResponse("", "", http.StatusAccepted)
+		c.sendResponse(writer, request, sdkCommon.ApiAutoEventTriggerRoute, res, http.StatusAccepted)
+		return
+	}
+	c.sendEdgexError(writer, request, err, sdkCommon.ApiAutoEventTriggerRoute)
+}
+
+// autoEventController returns the AutoEventManager as an AutoEventController, or an error if
+// it does not implement it.
+func (c *RestController) autoEventController() (sdkModels.AutoEventController, errors.EdgeX) {
+	controller, ok := container.ManagerFrom(c.dic.Get).(sdkModels.AutoEventController)
+	if !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindNotImplemented, "the AutoEventManager does not control the AutoEvents", nil)
+	}
+	return controller, nil
+}
+
+func (c *RestController) sendAutoEventResponse(writer http.ResponseWriter, request *http.Request, err errors.EdgeX, route string) {
+	if err != nil {
+		c.sendEdgexError(writer, request, err, route)
+		return
+	}
+	res := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, route, res, http.StatusOK)
+}
@@ -0,0 +1,142 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+// controlledManager is an AutoEventManager implementing AutoEventController, as the one of the SDK
+type controlledManager struct {
+	*mocks.AutoEventManager
+	*mocks.AutoEventController
+}
+
+func mockAutoEventDic(controller *mocks.AutoEventController) *di.Container {
+	return mockManagerDic(controlledManager{&mocks.AutoEventManager{}, controller})
+}
+
+func mockManagerDic(manager sdkModels.AutoEventManager) *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ManagerName: func(get di.Get) interface{} {
+			return manager
+		},
+	})
+}
+
+func TestRestController_AutoEventStatuses(t *testing.T) {
+	status := sdkDTOs.AutoEventStatus{DeviceName: "test-device", SourceName: "test-resource", Interval: "10s", Runs: 3, EventsSent: 2, EventsSuppressed: 1}
+	manager := &mocks.AutoEventController{}
+	manager.On("AutoEventStatuses", "").Return([]sdkDTOs.AutoEventStatus{status}, nil)
+	manager.On("AutoEventStatuses", "test-device").Return([]sdkDTOs.AutoEventStatus{status}, nil)
+	manager.On("AutoEventStatuses", "unknown-device").Return(nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	controller := NewRestController(mux.NewRouter(), mockAutoEventDic(manager), uuid.NewString())
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"all devices", "", http.StatusOK},
+		{"device", "test-device", http.StatusOK},
+		{"unknown device", "unknown-device", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, sdkCommon.ApiAllAutoEventRoute, http.NoBody)
+			require.NoError(t, err)
+			if tt.deviceName != "" {
+				req = mux.SetURLVars(req, map[string]string{common.Name: tt.deviceName})
+			}
+			recorder := httptest.NewRecorder()
+			http.HandlerFunc(controller.AutoEventStatuses).ServeHTTP(recorder, req)
+
+			require.Equal(t, tt.expectedStatusCode, recorder.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res sdkResponses.MultiAutoEventStatusResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, []sdkDTOs.AutoEventStatus{status}, res.AutoEvents)
+		})
+	}
+}
+
+func TestRestController_AutoEventControl(t *testing.T) {
+	notFound := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil)
+	conflict := errors.NewCommonEdgeX(errors.KindStatusConflict, "already reading", nil)
+	manager := &mocks.AutoEventController{}
+	manager.On("PauseAutoEvent", "test-device", "test-resource").Return(nil)
+	manager.On("PauseAutoEvent", "test-device", "unknown-resource").Return(notFound)
+	manager.On("ResumeAutoEvent", "test-device", "test-resource").Return(nil)
+	manager.On("TriggerAutoEvent", "test-device", "test-resource").Return(nil)
+	manager.On("TriggerAutoEvent", "busy-device", "test-resource").Return(conflict)
+	controller := NewRestController(mux.NewRouter(), mockAutoEventDic(manager), uuid.NewString())
+
+	tests := []struct {
+		name               string
+		handler            http.HandlerFunc
+		deviceName         string
+		sourceName         string
+		expectedStatusCode int
+	}{
+		{"pause", controller.PauseAutoEvent, "test-device", "test-resource", http.StatusOK},
+		{"pause unknown source", controller.PauseAutoEvent, "test-device", "unknown-resource", http.StatusNotFound},
+		{"resume", controller.ResumeAutoEvent, "test-device", "test-resource", http.StatusOK},
+		{"trigger", controller.TriggerAutoEvent, "test-device", "test-resource", http.StatusAccepted},
+		{"trigger while reading", controller.TriggerAutoEvent, "busy-device", "test-resource", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, sdkCommon.ApiAutoEventRoute, http.NoBody)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{common.Name: tt.deviceName, common.SourceName: tt.sourceName})
+			recorder := httptest.NewRecorder()
+			tt.handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+		})
+	}
+}
+
+func TestRestController_AutoEventNotControlled(t *testing.T) {
+	controller := NewRestController(mux.NewRouter(), mockManagerDic(&mocks.AutoEventManager{}), uuid.NewString())
+
+	for _, handler := range []http.HandlerFunc{controller.AutoEventStatuses, controller.PauseAutoEvent, controller.ResumeAutoEvent, controller.TriggerAutoEvent} {
+		req, err := http.NewRequest(http.MethodPost, sdkCommon.ApiAutoEventRoute, http.NoBody)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{common.Name: "test-device", common.SourceName: "test-resource"})
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+	}
+}
@@ -0,0 +1,124 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkRequests "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/requests"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+)
+
+// BatchCommand executes the device commands listed in the request body, at most
+// Device.BatchCommand.Parallelism at the same time, and responds with the outcome of
+// each of them in the request order.
+func (c *RestController) BatchCommand(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	correlationID := request.Header.Get(common.CorrelationHeader)
+	if correlationID == "" {
+		correlationID, _ = request.Context().Value(common.CorrelationHeader).(string)
+	}
+	config := container.ConfigurationFrom(c.dic.Get)
+
+	body, edgexErr := readRequestBody(request, config.Service.MaxRequestSize)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDeviceCommandBatchRoute)
+		return
+	}
+	var batchRequest sdkRequests.BatchCommandRequest
+	err := json.Unmarshal(body, &batchRequest)
+	if err != nil {
+		edgexErr = errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse batch command request", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDeviceCommandBatchRoute)
+		return
+	}
+	maxCommands := config.Device.BatchCommand.MaxCommands
+	if maxCommands > 0 && len(batchRequest.Commands) > maxCommands {
+		errMsg := fmt.Sprintf("batch of %d commands exceeds Device.BatchCommand.MaxCommands(%d)", len(batchRequest.Commands), maxCommands)
+		edgexErr = errors.NewCommonEdgeX(errors.KindLimitExceeded, errMsg, nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDeviceCommandBatchRoute)
+		return
+	}
+
+	parallelism := config.Device.BatchCommand.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([]sdkDTOs.BatchCommandResult, len(batchRequest.Commands))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, cmd := range batchRequest.Commands {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, cmd sdkDTOs.BatchCommand) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i] = c.batchCommandResult(request.Context(), correlationID, cmd)
+		}(i, cmd)
+	}
+	wg.Wait()
+
+	res := sdkResponses.NewBatchCommandResponse(batchRequest.RequestId, "", http.StatusMultiStatus, results)
+	c.sendResponse(writer, request, sdkCommon.ApiDeviceCommandBatchRoute, res, http.StatusMultiStatus)
+}
+
+// batchCommandResult executes one command of a batch request as the equivalent single
+// command request would, and reports its outcome.
+func (c *RestController) batchCommandResult(ctx context.Context, correlationID string, cmd sdkDTOs.BatchCommand) sdkDTOs.BatchCommandResult {
+	vars := map[string]string{common.Name: cmd.DeviceName, common.Command: cmd.CommandName}
+	query := make(url.Values)
+	for k, v := range cmd.QueryParams {
+		query.Set(k, v)
+	}
+	isRead := cmd.Method == http.MethodGet
+	requestParamsMap := cmd.Parameters
+	if !isRead && requestParamsMap == nil {
+		requestParamsMap = make(map[string]interface{})
+	}
+
+	res := application.ExecuteCommand(ctx, isRead, correlationID, vars, requestParamsMap, query.Encode(), c.dic)
+	result := sdkDTOs.BatchCommandResult{
+		DeviceName:  cmd.DeviceName,
+		CommandName: cmd.CommandName,
+		StatusCode:  http.StatusOK,
+	}
+	if res.Transaction != nil {
+		result.Operations = res.Transaction.Operations
+	}
+	if res.Err != nil {
+		c.lc.Error(res.Err.Error(), common.CorrelationHeader, correlationID)
+		c.lc.Debug(res.Err.DebugMessages(), common.CorrelationHeader, correlationID)
+		result.StatusCode = res.Err.Code()
+		result.Message = res.Err.Error()
+		return result
+	}
+
+	if failedResources := application.FailedResources(res.Event); len(failedResources) > 0 {
+		result.StatusCode = http.StatusMultiStatus
+		result.FailedResources = failedResources
+	}
+	// return event in the result if specified (default yes)
+	if res.ReturnEvent {
+		result.Event = res.Event
+	}
+	return result
+}
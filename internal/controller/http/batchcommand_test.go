@@ -0,0 +1,173 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	sdkRequests "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/requests"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+func mockBatchCommandDic(t *testing.T) *di.Container {
+	cv, err := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "test-value")
+	require.NoError(t, err)
+	driverMock := &mocks.ProtocolDriver{}
+	driverMock.On("HandleReadCommands", "test-device", mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{cv}, nil)
+	driverMock.On("HandleWriteCommands", "test-device", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	device := dtos.Device{
+		Name:           "test-device",
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		ServiceName:    "test-service",
+		ProfileName:    "test-profile",
+	}
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), "test-service", 0, -1).Return(responses.MultiDevicesResponse{Devices: []dtos.Device{device}}, nil)
+
+	profile := dtos.DeviceProfile{
+		Name: "test-profile",
+		DeviceResources: []dtos.DeviceResource{{
+			Name:       "test-resource",
+			Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_RW},
+		}},
+		DeviceCommands: []dtos.DeviceCommand{{
+			Name:               "test-command",
+			ReadWrite:          common.ReadWrite_RW,
+			ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "test-resource"}},
+		}},
+	}
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), "test-profile").Return(responses.DeviceProfileResponse{Profile: profile}, nil)
+
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", context.Background(), "test-service", 0, -1).Return(responses.MultiProvisionWatchersResponse{}, nil)
+
+	configuration := &config.ConfigurationStruct{
+		Device: config.DeviceInfo{
+			MaxCmdOps:    1,
+			BatchCommand: config.BatchCommandInfo{MaxCommands: 4, Parallelism: 2},
+		},
+	}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driverMock
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &models.DeviceService{Name: "test-service", AdminState: models.Unlocked}
+		},
+	})
+	require.NoError(t, cache.InitCache("test-service", dic))
+
+	return dic
+}
+
+func TestRestController_BatchCommand(t *testing.T) {
+	dic := mockBatchCommandDic(t)
+
+	read := sdkDTOs.BatchCommand{DeviceName: "test-device", CommandName: "test-command", Method: http.MethodGet}
+	readNoEvent := read
+	readNoEvent.QueryParams = map[string]string{common.ReturnEvent: common.ValueNo}
+	write := sdkDTOs.BatchCommand{DeviceName: "test-device", CommandName: "test-command", Method: http.MethodPut, Parameters: map[string]interface{}{"test-resource": "value"}}
+	unknownDevice := read
+	unknownDevice.DeviceName = "unknown-device"
+	invalidTimeout := read
+	invalidTimeout.QueryParams = map[string]string{sdkCommon.Timeout: "invalid"}
+	invalidMethod := read
+	invalidMethod.Method = http.MethodPost
+
+	tests := []struct {
+		name                string
+		commands            []sdkDTOs.BatchCommand
+		expectedStatusCode  int
+		expectedResultCodes []int
+		expectedEvents      []bool
+	}{
+		{"valid", []sdkDTOs.BatchCommand{read, readNoEvent, write}, http.StatusMultiStatus, []int{http.StatusOK, http.StatusOK, http.StatusOK}, []bool{true, false, false}},
+		{"valid - failed commands", []sdkDTOs.BatchCommand{unknownDevice, read, invalidTimeout}, http.StatusMultiStatus, []int{http.StatusNotFound, http.StatusOK, http.StatusBadRequest}, []bool{false, true, false}},
+		{"invalid - no commands", []sdkDTOs.BatchCommand{}, http.StatusBadRequest, nil, nil},
+		{"invalid - unsupported method", []sdkDTOs.BatchCommand{invalidMethod}, http.StatusBadRequest, nil, nil},
+		{"invalid - exceeding MaxCommands", []sdkDTOs.BatchCommand{read, read, read, read, read}, http.StatusRequestEntityTooLarge, nil, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			batchRequest := sdkRequests.BatchCommandRequest{BaseRequest: commonDTO.NewBaseRequest(), Commands: tt.commands}
+			jsonData, err := json.Marshal(batchRequest)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, sdkCommon.ApiDeviceCommandBatchRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			controller := NewRestController(mux.NewRouter(), dic, uuid.NewString())
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.BatchCommand)
+			handler.ServeHTTP(recorder, req)
+
+			require.Equal(t, tt.expectedStatusCode, recorder.Result().StatusCode, "Wrong status code")
+			if tt.expectedResultCodes == nil {
+				return
+			}
+			var res sdkResponses.BatchCommandResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, batchRequest.RequestId, res.RequestId)
+			require.Len(t, res.Results, len(tt.commands))
+			for i, result := range res.Results {
+				assert.Equal(t, tt.commands[i].DeviceName, result.DeviceName)
+				assert.Equal(t, tt.expectedResultCodes[i], result.StatusCode)
+				assert.Equal(t, tt.expectedEvents[i], result.Event != nil)
+				if result.StatusCode != http.StatusOK {
+					assert.NotEmpty(t, result.Message)
+				}
+			}
+		})
+	}
+}
@@ -11,8 +11,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
@@ -21,17 +19,15 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
-	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
 )
 
 func (c *RestController) Command(writer http.ResponseWriter, request *http.Request) {
 	defer request.Body.Close()
 
 	var requestParamsMap map[string]interface{}
-	var queryParams string
 	var err errors.EdgeX
-	var reserved url.Values
 	vars := mux.Vars(request)
 	correlationID := request.Header.Get(common.CorrelationHeader)
 	if correlationID == "" {
@@ -46,29 +42,24 @@ func (c *RestController) Command(writer http.ResponseWriter, request *http.Reque
 			return
 		}
 	}
-	// parse query parameter
-	queryParams, reserved, err = filterQueryParams(request.URL.RawQuery)
-	if err != nil {
-		c.sendEdgexError(writer, request, err, common.ApiDeviceNameCommandNameRoute)
-		return
-	}
 
-	var sendEvent bool
-	// push event to CoreData if specified (default no)
-	if ok, exist := reserved[common.PushEvent]; exist && ok[0] == common.ValueYes {
-		sendEvent = true
-	}
 	isRead := request.Method == http.MethodGet
-	eventDTO, err := application.CommandHandler(isRead, sendEvent, correlationID, vars, requestParamsMap, queryParams, c.dic)
-	if err != nil {
-		c.sendEdgexError(writer, request, err, common.ApiDeviceNameCommandNameRoute)
+	result := application.ExecuteCommand(request.Context(), isRead, correlationID, vars, requestParamsMap, request.URL.RawQuery, c.dic)
+	if result.Transaction != nil && result.Transaction.Operations != nil {
+		c.sendTransactionResponse(writer, request, result.Transaction, result.Err)
+		return
+	}
+	if result.Err != nil {
+		c.sendEdgexError(writer, request, result.Err, common.ApiDeviceNameCommandNameRoute)
 		return
 	}
 
-	// return event in http response if specified (default yes)
-	if ok, exist := reserved[common.ReturnEvent]; !exist || ok[0] == common.ValueYes {
-		if eventDTO != nil {
-			res := responses.NewEventResponse("", "", http.StatusOK, *eventDTO)
+	if result.ReturnEvent {
+		if failedResources := application.FailedResources(result.Event); len(failedResources) > 0 {
+			res := sdkResponses.NewPartialEventResponse("", "", http.StatusMultiStatus, *result.Event, failedResources)
+			c.sendPartialEventResponse(writer, request, res, http.StatusMultiStatus)
+		} else if result.Event != nil {
+			res := responses.NewEventResponse("", "", http.StatusOK, *result.Event)
 			c.sendEventResponse(writer, request, res, http.StatusOK)
 		} else {
 			res := commonDTO.NewBaseResponse("", "", http.StatusOK)
@@ -77,15 +68,25 @@ func (c *RestController) Command(writer http.ResponseWriter, request *http.Reque
 	}
 }
 
-func parseRequestBody(req *http.Request, maxRequestSize int64) (map[string]interface{}, errors.EdgeX) {
-	defer req.Body.Close()
-	body, err := io.ReadAll(req.Body)
+// sendTransactionResponse reports the outcome of each resource operation of a transactional
+// SET command, along with the error of the command if any.
+func (c *RestController) sendTransactionResponse(writer http.ResponseWriter, request *http.Request, tx *application.Transaction, err errors.EdgeX) {
 	if err != nil {
-		if err.Error() == "http: request body too large" {
-			errMsg := fmt.Sprintf("request size exceed Service.MaxRequestSize(%d)", maxRequestSize)
-			return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded, errMsg, err)
-		}
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to read request body", err)
+		correlationID := request.Header.Get(common.CorrelationHeader)
+		c.lc.Error(err.Error(), common.CorrelationHeader, correlationID)
+		c.lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationID)
+		res := sdkResponses.NewWriteTransactionResponse("", err.Error(), err.Code(), tx.Operations)
+		c.sendResponse(writer, request, common.ApiDeviceNameCommandNameRoute, res, err.Code())
+		return
+	}
+	res := sdkResponses.NewWriteTransactionResponse("", "", http.StatusOK, tx.Operations)
+	c.sendResponse(writer, request, common.ApiDeviceNameCommandNameRoute, res, http.StatusOK)
+}
+
+func parseRequestBody(req *http.Request, maxRequestSize int64) (map[string]interface{}, errors.EdgeX) {
+	body, edgexErr := readRequestBody(req, maxRequestSize)
+	if edgexErr != nil {
+		return nil, edgexErr
 	}
 
 	var paramMap = make(map[string]interface{})
@@ -93,7 +94,7 @@ func parseRequestBody(req *http.Request, maxRequestSize int64) (map[string]inter
 		return paramMap, nil
 	}
 
-	err = json.Unmarshal(body, &paramMap)
+	err := json.Unmarshal(body, &paramMap)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse SET command parameters", err)
 	}
@@ -101,21 +102,15 @@ func parseRequestBody(req *http.Request, maxRequestSize int64) (map[string]inter
 	return paramMap, nil
 }
 
-func filterQueryParams(queryParams string) (string, url.Values, errors.EdgeX) {
-	m, err := url.ParseQuery(queryParams)
+func readRequestBody(req *http.Request, maxRequestSize int64) ([]byte, errors.EdgeX) {
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		edgexErr := errors.NewCommonEdgeX(errors.KindServerError, "failed to parse query parameter", err)
-		return "", nil, edgexErr
-	}
-
-	var reserved = make(url.Values)
-	// Separate parameters with SDK reserved prefix
-	for k := range m {
-		if strings.HasPrefix(k, sdkCommon.SDKReservedPrefix) {
-			reserved.Set(k, m.Get(k))
-			delete(m, k)
+		if err.Error() == "http: request body too large" {
+			errMsg := fmt.Sprintf("request size exceed Service.MaxRequestSize(%d)", maxRequestSize)
+			return nil, errors.NewCommonEdgeX(errors.KindLimitExceeded, errMsg, err)
 		}
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to read request body", err)
 	}
-
-	return m.Encode(), reserved, nil
+	return body, nil
 }
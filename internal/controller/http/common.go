@@ -19,6 +19,7 @@ import (
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
 )
 
 // Ping handles the request to /ping endpoint. Is used to test if the service is working
@@ -42,7 +43,8 @@ func (c *RestController) Config(writer http.ResponseWriter, request *http.Reques
 	c.sendResponse(writer, request, common.ApiVersionRoute, response, http.StatusOK)
 }
 
-// Metrics handles the request to the /metrics endpoint, memory and cpu utilization stats
+// Metrics handles the request to the /metrics endpoint, memory and cpu utilization stats along
+// with the metrics of the device service
 // It returns a response as specified by the V2 API swagger in openapi/common
 func (c *RestController) Metrics(writer http.ResponseWriter, request *http.Request) {
 	telem := telemetry.NewSystemUsage()
@@ -56,7 +58,7 @@ func (c *RestController) Metrics(writer http.ResponseWriter, request *http.Reque
 		CpuBusyAvg:     uint8(telem.CpuBusyAvg),
 	}
 
-	response := commonDTO.NewMetricsResponse(metrics, c.serviceName)
+	response := sdkResponses.NewMetricsResponse(metrics, c.serviceName, telemetry.Metrics())
 	c.sendResponse(writer, request, common.ApiMetricsRoute, response, http.StatusOK)
 }
 
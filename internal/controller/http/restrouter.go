@@ -22,8 +22,10 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/gorilla/mux"
 
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/controller/http/correlation"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
 )
 
 type RestController struct {
@@ -60,6 +62,13 @@ func (c *RestController) InitRestRoutes() {
 	c.addReservedRoute(common.ApiDeviceValidationRoute, c.ValidateDevice).Methods(http.MethodPost)
 	// device command
 	c.addReservedRoute(common.ApiDeviceNameCommandNameRoute, c.Command).Methods(http.MethodPut, http.MethodGet)
+	c.addReservedRoute(sdkCommon.ApiDeviceCommandBatchRoute, c.BatchCommand).Methods(http.MethodPost)
+	// AutoEvent
+	c.addReservedRoute(sdkCommon.ApiAllAutoEventRoute, c.AutoEventStatuses).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.ApiAutoEventByDeviceNameRoute, c.AutoEventStatuses).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.ApiAutoEventPauseRoute, c.PauseAutoEvent).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.ApiAutoEventResumeRoute, c.ResumeAutoEvent).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.ApiAutoEventTriggerRoute, c.TriggerAutoEvent).Methods(http.MethodPost)
 	// callback
 	c.addReservedRoute(common.ApiDeviceCallbackRoute, c.AddDevice).Methods(http.MethodPost)
 	c.addReservedRoute(common.ApiDeviceCallbackRoute, c.UpdateDevice).Methods(http.MethodPut)
@@ -153,6 +162,32 @@ func (c *RestController) sendEventResponse(
 	}
 }
 
+func (c *RestController) sendPartialEventResponse(
+	writer http.ResponseWriter,
+	request *http.Request,
+	response sdkResponses.PartialEventResponse,
+	statusCode int) {
+
+	correlationID := request.Header.Get(common.CorrelationHeader)
+	data, encoding, err := response.Encode()
+	if err != nil {
+		c.lc.Errorf("Unable to marshal PartialEventResponse: %s; %s: %s", err.Error(), common.CorrelationHeader, correlationID)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set(common.CorrelationHeader, correlationID)
+	writer.Header().Set(common.ContentType, encoding)
+	writer.WriteHeader(statusCode)
+
+	_, err = writer.Write(data)
+	if err != nil {
+		c.lc.Errorf("Unable to write DeviceCommand response: %s; %s: %s", err.Error(), common.CorrelationHeader, correlationID)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (c *RestController) sendEdgexError(
 	writer http.ResponseWriter,
 	request *http.Request,
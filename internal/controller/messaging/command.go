@@ -0,0 +1,175 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkRequests "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/requests"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+)
+
+// SubscribeCommands subscribes to MessageQueue.SubscribeTopic when MessageQueue.SubscribeEnabled
+// is set, and executes the device commands received on it, at most Device.CommandParallelism at
+// the same time, until ctx is done. The response of each command is published to
+// Device.CommandResponseTopicPrefix followed by the request ID.
+func SubscribeCommands(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+	if !config.MessageQueue.SubscribeEnabled {
+		return nil
+	}
+	msgClient := container.MessagingClientFrom(dic.Get)
+	if msgClient == nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "MessageQueue.SubscribeEnabled requires Device.UseMessageBus", nil)
+	}
+
+	requestTopic := config.MessageQueue.SubscribeTopic
+	messages := make(chan types.MessageEnvelope)
+	messageErrors := make(chan error)
+	topics := []types.TopicChannel{{Topic: requestTopic, Messages: messages}}
+	if err := msgClient.Subscribe(topics, messageErrors); err != nil {
+		return errors.NewCommonEdgeX(errors.KindCommunicationError, fmt.Sprintf("failed to subscribe to command request topic %s", requestTopic), err)
+	}
+
+	parallelism := config.Device.CommandParallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var commands sync.WaitGroup
+		defer commands.Wait()
+		// commands are executed concurrently so that a slow device does not hold up the
+		// others, the per-device limits still apply. A slot is taken before the next request
+		// is received so that the requests in excess wait on the MessageBus.
+		slots := make(chan struct{}, parallelism)
+		for {
+			select {
+			case <-ctx.Done():
+				lc.Infof("Exiting waiting for MessageBus '%s' topic messages", requestTopic)
+				return
+			case slots <- struct{}{}:
+			}
+			select {
+			case <-ctx.Done():
+				lc.Infof("Exiting waiting for MessageBus '%s' topic messages", requestTopic)
+				return
+			case err := <-messageErrors:
+				lc.Errorf("Failed to receive command request from MessageBus: %v", err)
+				<-slots
+			case msgEnvelope := <-messages:
+				commands.Add(1)
+				go func() {
+					defer func() {
+						<-slots
+						commands.Done()
+					}()
+					executeCommand(ctx, msgEnvelope, dic)
+				}()
+			}
+		}
+	}()
+
+	lc.Infof("Subscribed to MessageBus '%s' topic for device commands", requestTopic)
+	return nil
+}
+
+// executeCommand executes the device command of a request received from the MessageBus and
+// publishes its response. Requests without a request ID cannot be answered and are dropped.
+func executeCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	correlationID := msgEnvelope.CorrelationID
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+
+	var req sdkRequests.CommandRequest
+	err := json.Unmarshal(msgEnvelope.Payload, &req)
+	if err != nil {
+		var base commonDTO.BaseRequest
+		if json.Unmarshal(msgEnvelope.Payload, &base) != nil || base.RequestId == "" {
+			lc.Errorf("Dropped command request received on %s: %v; %s: %s", msgEnvelope.ReceivedTopic, err, common.CorrelationHeader, correlationID)
+			return
+		}
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse command request", err)
+		publishResponse(sdkResponses.NewCommandResponse(base.RequestId, edgexErr.Error(), edgexErr.Code()), correlationID, dic)
+		return
+	}
+
+	vars := map[string]string{common.Name: req.DeviceName, common.Command: req.CommandName}
+	query := make(url.Values)
+	for k, v := range req.QueryParams {
+		query.Set(k, v)
+	}
+	isRead := req.Method == http.MethodGet
+	requestParamsMap := req.Parameters
+	if !isRead && requestParamsMap == nil {
+		requestParamsMap = make(map[string]interface{})
+	}
+
+	result := application.ExecuteCommand(ctx, isRead, correlationID, vars, requestParamsMap, query.Encode(), dic)
+	res := sdkResponses.NewCommandResponse(req.RequestId, "", http.StatusOK)
+	if result.Transaction != nil {
+		res.Operations = result.Transaction.Operations
+	}
+	if result.Err != nil {
+		lc.Error(result.Err.Error(), common.CorrelationHeader, correlationID)
+		lc.Debug(result.Err.DebugMessages(), common.CorrelationHeader, correlationID)
+		res.StatusCode = result.Err.Code()
+		res.Message = result.Err.Error()
+		publishResponse(res, correlationID, dic)
+		return
+	}
+
+	if failedResources := application.FailedResources(result.Event); len(failedResources) > 0 {
+		res.StatusCode = http.StatusMultiStatus
+		res.FailedResources = failedResources
+	}
+	// return event in the response if specified (default yes)
+	if result.ReturnEvent {
+		res.Event = result.Event
+	}
+	publishResponse(res, correlationID, dic)
+}
+
+func publishResponse(res sdkResponses.CommandResponse, correlationID string, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+
+	payload, err := json.Marshal(res)
+	if err != nil {
+		lc.Errorf("Failed to encode command response: %v; %s: %s", err, common.CorrelationHeader, correlationID)
+		return
+	}
+	envelope := types.MessageEnvelope{
+		CorrelationID: correlationID,
+		Payload:       payload,
+		ContentType:   common.ContentTypeJSON,
+	}
+	responseTopic := fmt.Sprintf("%s/%s", config.Device.CommandResponseTopicPrefix, res.RequestId)
+	err = container.MessagingClientFrom(dic.Get).Publish(envelope, responseTopic)
+	if err != nil {
+		lc.Errorf("Failed to publish command response to MessageBus topic %s: %v; %s: %s", responseTopic, err, common.CorrelationHeader, correlationID)
+	}
+}
@@ -0,0 +1,325 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkRequests "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/requests"
+	sdkResponses "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos/responses"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models/mocks"
+)
+
+const (
+	testRequestTopic        = "edgex/device/command/request/test-service"
+	testResponseTopicPrefix = "edgex/device/command/response/test-service"
+)
+
+type publishedMessage struct {
+	envelope types.MessageEnvelope
+	topic    string
+}
+
+// messageClient is a MessageClient delivering the messages sent on its request channel to
+// the subscriber and capturing the published ones.
+type messageClient struct {
+	requests  chan types.MessageEnvelope
+	published chan publishedMessage
+}
+
+func (c *messageClient) Connect() error {
+	return nil
+}
+
+func (c *messageClient) Publish(message types.MessageEnvelope, topic string) error {
+	c.published <- publishedMessage{envelope: message, topic: topic}
+	return nil
+}
+
+func (c *messageClient) Subscribe(topics []types.TopicChannel, _ chan error) error {
+	go func() {
+		for msg := range c.requests {
+			msg.ReceivedTopic = topics[0].Topic
+			topics[0].Messages <- msg
+		}
+	}()
+	return nil
+}
+
+func (c *messageClient) Disconnect() error {
+	return nil
+}
+
+func mockDic(t *testing.T, msgClient *messageClient) *di.Container {
+	cv, err := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "test-value")
+	require.NoError(t, err)
+	driverMock := &mocks.ProtocolDriver{}
+	driverMock.On("HandleReadCommands", "test-device", mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{cv}, nil)
+
+	device := dtos.Device{
+		Name:           "test-device",
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		ServiceName:    "test-service",
+		ProfileName:    "test-profile",
+	}
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", context.Background(), "test-service", 0, -1).Return(responses.MultiDevicesResponse{Devices: []dtos.Device{device}}, nil)
+
+	profile := dtos.DeviceProfile{
+		Name: "test-profile",
+		DeviceResources: []dtos.DeviceResource{{
+			Name:       "test-resource",
+			Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_RW},
+		}},
+		DeviceCommands: []dtos.DeviceCommand{{
+			Name:               "test-command",
+			ReadWrite:          common.ReadWrite_RW,
+			ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "test-resource"}},
+		}},
+	}
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), "test-profile").Return(responses.DeviceProfileResponse{Profile: profile}, nil)
+
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", context.Background(), "test-service", 0, -1).Return(responses.MultiProvisionWatchersResponse{}, nil)
+
+	configuration := &config.ConfigurationStruct{
+		Device: config.DeviceInfo{
+			MaxCmdOps:                  1,
+			UseMessageBus:              true,
+			CommandResponseTopicPrefix: testResponseTopicPrefix,
+		},
+		MessageQueue: bootstrapConfig.MessageBusInfo{
+			SubscribeEnabled: true,
+			SubscribeTopic:   testRequestTopic,
+		},
+	}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driverMock
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &models.DeviceService{Name: "test-service", AdminState: models.Unlocked}
+		},
+		container.MessagingClientName: func(get di.Get) interface{} {
+			return msgClient
+		},
+	})
+	require.NoError(t, cache.InitCache("test-service", dic))
+
+	return dic
+}
+
+func TestSubscribeCommands(t *testing.T) {
+	msgClient := &messageClient{
+		requests:  make(chan types.MessageEnvelope),
+		published: make(chan publishedMessage, 1),
+	}
+	defer close(msgClient.requests)
+	dic := mockDic(t, msgClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	err := SubscribeCommands(ctx, wg, dic)
+	require.NoError(t, err)
+
+	read := sdkRequests.CommandRequest{BaseRequest: commonDTO.NewBaseRequest(), DeviceName: "test-device", CommandName: "test-command", Method: http.MethodGet}
+	readNoEvent := read
+	readNoEvent.RequestId = uuid.NewString()
+	readNoEvent.QueryParams = map[string]string{common.ReturnEvent: common.ValueNo}
+	unknownDevice := read
+	unknownDevice.RequestId = uuid.NewString()
+	unknownDevice.DeviceName = "unknown-device"
+	invalidMethod := read
+	invalidMethod.RequestId = uuid.NewString()
+	invalidMethod.Method = http.MethodPost
+
+	tests := []struct {
+		name               string
+		request            sdkRequests.CommandRequest
+		expectedStatusCode int
+		expectedEvent      bool
+	}{
+		{"valid", read, http.StatusOK, true},
+		{"valid - ds-returnevent=no", readNoEvent, http.StatusOK, false},
+		{"invalid - device not found", unknownDevice, http.StatusNotFound, false},
+		{"invalid - unsupported method", invalidMethod, http.StatusBadRequest, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(tt.request)
+			require.NoError(t, err)
+			correlationID := uuid.NewString()
+			msgClient.requests <- types.MessageEnvelope{CorrelationID: correlationID, Payload: payload, ContentType: common.ContentTypeJSON}
+
+			var published publishedMessage
+			select {
+			case published = <-msgClient.published:
+			case <-time.After(time.Second):
+				require.Fail(t, "command response not published")
+			}
+			assert.Equal(t, testResponseTopicPrefix+"/"+tt.request.RequestId, published.topic)
+			assert.Equal(t, correlationID, published.envelope.CorrelationID)
+
+			var res sdkResponses.CommandResponse
+			err = json.Unmarshal(published.envelope.Payload, &res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.request.RequestId, res.RequestId)
+			assert.Equal(t, tt.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, tt.expectedEvent, res.Event != nil)
+		})
+	}
+
+	t.Run("invalid - no request ID", func(t *testing.T) {
+		noRequestId := read
+		noRequestId.RequestId = ""
+		payload, err := json.Marshal(noRequestId)
+		require.NoError(t, err)
+		msgClient.requests <- types.MessageEnvelope{Payload: payload, ContentType: common.ContentTypeJSON}
+
+		select {
+		case published := <-msgClient.published:
+			assert.Fail(t, "unexpected response published", published.topic)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func TestSubscribeCommands_Disabled(t *testing.T) {
+	configuration := &config.ConfigurationStruct{}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+	})
+
+	err := SubscribeCommands(context.Background(), &sync.WaitGroup{}, dic)
+	assert.NoError(t, err)
+
+	// subscribing requires the MessageBus client
+	configuration.MessageQueue.SubscribeEnabled = true
+	err = SubscribeCommands(context.Background(), &sync.WaitGroup{}, dic)
+	assert.Error(t, err)
+}
+
+func TestSubscribeCommands_Parallelism(t *testing.T) {
+	const parallelism = 2
+	msgClient := &messageClient{
+		requests:  make(chan types.MessageEnvelope),
+		published: make(chan publishedMessage, 5),
+	}
+	defer close(msgClient.requests)
+	dic := mockDic(t, msgClient)
+	container.ConfigurationFrom(dic.Get).Device.CommandParallelism = parallelism
+
+	var mutex sync.Mutex
+	var running, maxRunning int
+	release := make(chan struct{})
+	cv, err := sdkModels.NewCommandValue("test-resource", common.ValueTypeString, "test-value")
+	require.NoError(t, err)
+	driverMock := &mocks.ProtocolDriver{}
+	driverMock.On("HandleReadCommands", "test-device", mock.Anything, mock.Anything).Return([]*sdkModels.CommandValue{cv}, nil).
+		Run(func(mock.Arguments) {
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+			<-release
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+		})
+	dic.Update(di.ServiceConstructorMap{
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driverMock
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	require.NoError(t, SubscribeCommands(ctx, wg, dic))
+
+	// the requests in excess wait on the MessageBus while the driver is busy
+	go func() {
+		for i := 0; i < 5; i++ {
+			read := sdkRequests.CommandRequest{BaseRequest: commonDTO.NewBaseRequest(), DeviceName: "test-device", CommandName: "test-command", Method: http.MethodGet}
+			payload, _ := json.Marshal(read)
+			msgClient.requests <- types.MessageEnvelope{CorrelationID: uuid.NewString(), Payload: payload, ContentType: common.ContentTypeJSON}
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 5; i++ {
+		select {
+		case published := <-msgClient.published:
+			var res sdkResponses.CommandResponse
+			require.NoError(t, json.Unmarshal(published.envelope.Payload, &res))
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		case <-time.After(time.Second):
+			require.Fail(t, "command response not published")
+		}
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, parallelism, maxRunning)
+}
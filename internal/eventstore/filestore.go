@@ -0,0 +1,138 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const fileExtension = ".json"
+
+// FileStore is the default EventStore, which keeps each event in its own file of a directory.
+// The files are named after a sequence number so that the queue survives restarts in order.
+type FileStore struct {
+	dir string
+	// seqs holds the sequence numbers of the stored events in queue order
+	seqs  []uint64
+	next  uint64
+	mutex sync.Mutex
+}
+
+// NewFileStore opens the FileStore of dir, creating the directory if needed and resuming
+// the queue of the events already stored in it.
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event store directory %s: %v", dir, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event store directory %s: %v", dir, err)
+	}
+
+	s := &FileStore{dir: dir, next: 1}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileExtension) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, fileExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+		s.seqs = append(s.seqs, seq)
+		if seq >= s.next {
+			s.next = seq + 1
+		}
+	}
+	sort.Slice(s.seqs, func(i, j int) bool { return s.seqs[i] < s.seqs[j] })
+
+	return s, nil
+}
+
+// Append writes the event to a temporary file which is renamed once complete, so that
+// an interrupted write never leaves a partial event in the queue.
+func (s *FileStore) Append(event models.StoredEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode stored event: %v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := s.path(s.next)
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0640); err != nil {
+		return fmt.Errorf("failed to write stored event: %v", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write stored event: %v", err)
+	}
+	s.seqs = append(s.seqs, s.next)
+	s.next++
+	return nil
+}
+
+func (s *FileStore) Peek(limit int) ([]models.StoredEvent, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if limit > len(s.seqs) {
+		limit = len(s.seqs)
+	}
+	events := make([]models.StoredEvent, 0, limit)
+	for _, seq := range s.seqs[:limit] {
+		data, err := os.ReadFile(s.path(seq))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stored event: %v", err)
+		}
+		var event models.StoredEvent
+		if err = json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode stored event %s: %v", s.path(seq), err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *FileStore) Remove(count int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if count > len(s.seqs) {
+		count = len(s.seqs)
+	}
+	for i, seq := range s.seqs[:count] {
+		if err := os.Remove(s.path(seq)); err != nil && !os.IsNotExist(err) {
+			s.seqs = s.seqs[i:]
+			return fmt.Errorf("failed to remove stored event: %v", err)
+		}
+	}
+	s.seqs = s.seqs[count:]
+	return nil
+}
+
+func (s *FileStore) Len() (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.seqs), nil
+}
+
+func (s *FileStore) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, fileExtension))
+}
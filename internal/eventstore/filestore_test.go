@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func storedEvent(id string) models.StoredEvent {
+	return models.StoredEvent{
+		Event:         dtos.Event{Id: id, DeviceName: "test-device", SourceName: "test-resource"},
+		CorrelationID: "correlation-" + id,
+		Stored:        1,
+	}
+}
+
+func eventIds(events []models.StoredEvent) []string {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.Event.Id)
+	}
+	return ids
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, store.Append(storedEvent(id)))
+	}
+	n, err := store.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	events, err := store.Peek(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, eventIds(events))
+	assert.Equal(t, "correlation-1", events[0].CorrelationID)
+
+	require.NoError(t, store.Remove(1))
+	events, err = store.Peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, eventIds(events))
+
+	// the queue is resumed in order by a new store of the same directory, ignoring
+	// the files which are not stored events
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.json"), []byte("{}"), 0640))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00000000000000000009.json.tmp"), []byte("{"), 0640))
+	reopened, err := NewFileStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, reopened.Append(storedEvent("4")))
+	events, err = reopened.Peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3", "4"}, eventIds(events))
+
+	require.NoError(t, reopened.Remove(10))
+	n, err = reopened.Len()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	files, err := filepath.Glob(filepath.Join(dir, "0*.json"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,49 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// RingSink is the built-in EventSink keeping the last events of the device service in an
+// EventStore, e.g. for local inspection while the MessageBus or core-data is unreachable.
+// The oldest events are dropped beyond maxEvents.
+type RingSink struct {
+	store     models.EventStore
+	maxEvents int
+	mutex     sync.Mutex
+}
+
+// NewRingSink returns a RingSink keeping up to maxEvents events in store, 0 meaning unlimited.
+func NewRingSink(store models.EventStore, maxEvents int) *RingSink {
+	return &RingSink{store: store, maxEvents: maxEvents}
+}
+
+func (s *RingSink) Name() string {
+	return "local"
+}
+
+func (s *RingSink) Send(event dtos.Event, correlationID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.store.Append(models.StoredEvent{Event: event, CorrelationID: correlationID, Stored: time.Now().UnixNano()})
+	if err != nil || s.maxEvents <= 0 {
+		return err
+	}
+	depth, err := s.store.Len()
+	if err != nil || depth <= s.maxEvents {
+		return err
+	}
+	return s.store.Remove(depth - s.maxEvents)
+}
@@ -0,0 +1,28 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingSink(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	sink := NewRingSink(store, 2)
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, sink.Send(dtos.Event{Id: id}, "correlation-"+id))
+	}
+	events, err := store.Peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, eventIds(events))
+	assert.Equal(t, "correlation-3", events[1].CorrelationID)
+}
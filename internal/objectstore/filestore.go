@@ -0,0 +1,145 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// FileStore is the default ObjectStore, which keeps each object in a file of a directory.
+// Its locations are file URLs. The objects are kept until deleted, unless pruned by
+// StartPruning.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns the FileStore of dir, which is created along with the first object.
+func NewFileStore(dir string) (*FileStore, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store directory %s: %v", dir, err)
+	}
+	return &FileStore{dir: abs}, nil
+}
+
+// Put writes the object to a temporary file which is renamed once complete, so that an
+// interrupted write never leaves a partial object behind its location.
+func (s *FileStore) Put(name string, r io.Reader) (string, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return "", fmt.Errorf("failed to create object store directory: %v", err)
+	}
+
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return "", fmt.Errorf("failed to create object %s: %v", name, err)
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to write object %s: %v", name, err)
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(), nil
+}
+
+// Delete removes the file of the object.
+func (s *FileStore) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object %s: %v", name, err)
+	}
+	return nil
+}
+
+// Prune deletes the objects written before the given time, and returns their number.
+func (s *FileStore) Prune(before time.Time) (int, error) {
+	var pruned int
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		// the temporary files are the objects being written
+		if entry.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			return nil
+		}
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		pruned++
+		return nil
+	})
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune object store: %v", err)
+	}
+	return pruned, nil
+}
+
+// StartPruning deletes the objects older than retention, checked every half retention,
+// until ctx is done.
+func (s *FileStore) StartPruning(ctx context.Context, wg *sync.WaitGroup, retention time.Duration, lc logger.LoggingClient) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(retention / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pruned, err := s.Prune(time.Now().Add(-retention))
+				if err != nil {
+					lc.Errorf("%v", err)
+				}
+				if pruned > 0 {
+					lc.Debugf("Deleted %d objects older than %s from the object store", pruned, retention)
+				}
+			}
+		}
+	}()
+}
+
+// path returns the file of the object, which must be within the store directory.
+func (s *FileStore) path(name string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object name %s", name)
+	}
+	return path, nil
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectstore
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(_ []byte) (int, error) {
+	return 0, errors.New("device disconnected")
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	location, err := store.Put("camera/frame/1.bin", strings.NewReader("frame"))
+	require.NoError(t, err)
+	u, err := url.Parse(location)
+	require.NoError(t, err)
+	assert.Equal(t, "file", u.Scheme)
+	assert.Equal(t, filepath.Join(dir, "camera", "frame", "1.bin"), filepath.FromSlash(u.Path))
+	data, err := os.ReadFile(filepath.FromSlash(u.Path))
+	require.NoError(t, err)
+	assert.Equal(t, "frame", string(data))
+
+	require.NoError(t, store.Delete("camera/frame/1.bin"))
+	_, err = os.Stat(filepath.FromSlash(u.Path))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Delete("camera/frame/1.bin"), "deleting a missing object")
+}
+
+func TestFileStore_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Put("../outside.bin", strings.NewReader("frame"))
+	assert.Error(t, err)
+
+	// a failed read leaves no object behind
+	_, err = store.Put("camera/frame/2.bin", failingReader{})
+	assert.Error(t, err)
+	entries, err := os.ReadDir(filepath.Join(dir, "camera", "frame"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_Prune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, name := range []string{"camera/frame/1.bin", "camera/frame/2.bin"} {
+		_, err = store.Put(name, strings.NewReader("frame"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "camera", "frame", "1.bin"), old, old))
+	// an object being written is never pruned
+	tmp := filepath.Join(dir, "camera", "frame", "3.bin.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("fra"), 0640))
+	require.NoError(t, os.Chtimes(tmp, old, old))
+
+	pruned, err := store.Prune(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	_, err = os.Stat(filepath.Join(dir, "camera", "frame", "1.bin"))
+	assert.True(t, os.IsNotExist(err))
+	assert.FileExists(t, filepath.Join(dir, "camera", "frame", "2.bin"))
+	assert.FileExists(t, tmp)
+}
@@ -0,0 +1,62 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var registry = struct {
+	metrics map[string]*Metric
+	mutex   sync.Mutex
+}{metrics: make(map[string]*Metric)}
+
+// Metric is a named counter or gauge of the device service, reported by the /metrics endpoint.
+type Metric struct {
+	value int64
+}
+
+// NewMetric returns the metric registered under name, registering it first if needed.
+func NewMetric(name string) *Metric {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	m, ok := registry.metrics[name]
+	if !ok {
+		m = &Metric{}
+		registry.metrics[name] = m
+	}
+	return m
+}
+
+// Add adds delta to the value of the metric.
+func (m *Metric) Add(delta int64) {
+	atomic.AddInt64(&m.value, delta)
+}
+
+// Set replaces the value of the metric.
+func (m *Metric) Set(value int64) {
+	atomic.StoreInt64(&m.value, value)
+}
+
+// Value returns the value of the metric.
+func (m *Metric) Value() int64 {
+	return atomic.LoadInt64(&m.value)
+}
+
+// Metrics returns the current value of every registered metric by name.
+func Metrics() map[string]int64 {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	values := make(map[string]int64, len(registry.metrics))
+	for name, m := range registry.metrics {
+		values[name] = m.Value()
+	}
+	return values
+}
@@ -0,0 +1,178 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// deadband is the filtering of the readings of a device resource, given by its ds-deadband,
+// ds-deadbandpercent and ds-maxsilence attributes.
+type deadband struct {
+	absolute   float64
+	percent    float64
+	maxSilence time.Duration
+}
+
+// reportedReading is the last reading of a device resource which passed the deadband.
+type reportedReading struct {
+	value    string
+	numeric  float64
+	reported time.Time
+}
+
+var deadbands = &deadbandFilter{reported: make(map[string]map[string]reportedReading)}
+
+// deadbandFilter keeps the last reported reading of each device resource, by device name.
+type deadbandFilter struct {
+	reported map[string]map[string]reportedReading
+	mutex    sync.Mutex
+}
+
+// deadbandOf returns the deadband of the device resource, and false if the device resource
+// has none of the deadband attributes.
+func deadbandOf(dr models.DeviceResource) (deadband, bool) {
+	var db deadband
+	var found bool
+	if v, ok := dr.Attributes[sdkCommon.Deadband]; ok {
+		if f, err := strconv.ParseFloat(fmt.Sprint(v), 64); err == nil && f >= 0 {
+			db.absolute = f
+			found = true
+		}
+	}
+	if v, ok := dr.Attributes[sdkCommon.DeadbandPercent]; ok {
+		if f, err := strconv.ParseFloat(fmt.Sprint(v), 64); err == nil && f >= 0 {
+			db.percent = f
+			found = true
+		}
+	}
+	if v, ok := dr.Attributes[sdkCommon.MaxSilence]; ok {
+		if d, err := time.ParseDuration(fmt.Sprint(v)); err == nil && d > 0 {
+			db.maxSilence = d
+			found = true
+		}
+	}
+	return db, found
+}
+
+// FilterEvent drops the readings of the event which are within the deadband of their device
+// resource since the last reported reading, unless the max silence of the device resource
+// elapsed. The readings of the device resources without deadband are kept. It returns nil
+// if no reading is left.
+func FilterEvent(event *dtos.Event) *dtos.Event {
+	if event == nil {
+		return nil
+	}
+	now := time.Now()
+	readings := make([]dtos.BaseReading, 0, len(event.Readings))
+	for _, reading := range event.Readings {
+		dr, ok := cache.Profiles().DeviceResource(reading.ProfileName, reading.ResourceName)
+		if !ok {
+			readings = append(readings, reading)
+			continue
+		}
+		db, ok := deadbandOf(dr)
+		if !ok || deadbands.keep(event.DeviceName, reading, db, now) {
+			readings = append(readings, reading)
+		}
+	}
+	if len(readings) == 0 {
+		return nil
+	}
+	event.Readings = readings
+	return event
+}
+
+// keep indicates whether the reading differs from the last reported reading of its device
+// resource by more than the deadband, and records it as reported if so. Since the readings
+// are compared with the last reported one rather than the previous one, a slow drift is
+// still reported once it exceeds the deadband. The non-numeric readings are reported when
+// their value changes.
+func (f *deadbandFilter) keep(deviceName string, reading dtos.BaseReading, db deadband, now time.Time) bool {
+	if reading.ValueType == common.ValueTypeBinary {
+		// the binary readings are never within a deadband
+		return true
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	current := reportedReading{value: reading.Value, reported: now}
+	numeric := isNumeric(reading.ValueType)
+	if numeric {
+		var err error
+		if current.numeric, err = strconv.ParseFloat(reading.Value, 64); err != nil {
+			numeric = false
+		}
+	}
+
+	resources, ok := f.reported[deviceName]
+	if !ok {
+		resources = make(map[string]reportedReading)
+		f.reported[deviceName] = resources
+	}
+	last, ok := resources[reading.ResourceName]
+	switch {
+	case !ok:
+	case db.maxSilence > 0 && now.Sub(last.reported) >= db.maxSilence:
+	case numeric && exceedsDeadband(last.numeric, current.numeric, db):
+	case !numeric && last.value != current.value:
+	default:
+		return false
+	}
+	resources[reading.ResourceName] = current
+	return true
+}
+
+// exceedsDeadband indicates whether value differs from last by more than the absolute or
+// percent deadband. A deadband of 0 is ignored, unless both are 0 in which case any change
+// exceeds the deadband.
+func exceedsDeadband(last float64, value float64, db deadband) bool {
+	delta := math.Abs(value - last)
+	if db.absolute == 0 && db.percent == 0 {
+		return delta > 0
+	}
+	if db.absolute > 0 && delta > db.absolute {
+		return true
+	}
+	if db.percent > 0 {
+		if last == 0 {
+			return delta > 0
+		}
+		return delta/math.Abs(last)*100 > db.percent
+	}
+	return false
+}
+
+// ForgetDeadband drops the last reported readings of the device, so that its next readings
+// are reported regardless of the deadband.
+func ForgetDeadband(deviceName string) {
+	deadbands.mutex.Lock()
+	defer deadbands.mutex.Unlock()
+	delete(deadbands.reported, deviceName)
+}
+
+func isNumeric(valueType string) bool {
+	switch valueType {
+	case common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64,
+		common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64,
+		common.ValueTypeFloat32, common.ValueTypeFloat64:
+		return true
+	}
+	return false
+}
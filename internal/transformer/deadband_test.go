@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestDeadbandOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		expected   deadband
+		found      bool
+	}{
+		{"no deadband", map[string]interface{}{"address": 1}, deadband{}, false},
+		{"absolute", map[string]interface{}{sdkCommon.Deadband: 0.5}, deadband{absolute: 0.5}, true},
+		{"percent as string", map[string]interface{}{sdkCommon.DeadbandPercent: "2"}, deadband{percent: 2}, true},
+		{"max silence only", map[string]interface{}{sdkCommon.MaxSilence: "1m"}, deadband{maxSilence: time.Minute}, true},
+		{"invalid values", map[string]interface{}{sdkCommon.Deadband: -1, sdkCommon.MaxSilence: "invalid"}, deadband{}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db, found := deadbandOf(models.DeviceResource{Attributes: tt.attributes})
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, db)
+		})
+	}
+}
+
+func TestDeadbandFilter_Keep(t *testing.T) {
+	start := time.Now()
+	float := func(value string) dtos.BaseReading {
+		return dtos.BaseReading{ResourceName: "temperature", ValueType: common.ValueTypeFloat64, SimpleReading: dtos.SimpleReading{Value: value}}
+	}
+	str := func(value string) dtos.BaseReading {
+		return dtos.BaseReading{ResourceName: "status", ValueType: common.ValueTypeString, SimpleReading: dtos.SimpleReading{Value: value}}
+	}
+	type step struct {
+		reading  dtos.BaseReading
+		after    time.Duration
+		expected bool
+	}
+	tests := []struct {
+		name  string
+		db    deadband
+		steps []step
+	}{
+		{"absolute", deadband{absolute: 0.5}, []step{
+			{float("2.000000e+01"), 0, true},
+			{float("2.040000e+01"), 0, false},
+			{float("1.960000e+01"), 0, false},
+			{float("2.060000e+01"), 0, true},
+		}},
+		{"drift reported once beyond deadband", deadband{absolute: 0.5}, []step{
+			{float("2.000000e+01"), 0, true},
+			{float("2.030000e+01"), 0, false},
+			{float("2.045000e+01"), 0, false},
+			{float("2.060000e+01"), 0, true},
+		}},
+		{"percent", deadband{percent: 10}, []step{
+			{float("100"), 0, true},
+			{float("109"), 0, false},
+			{float("111"), 0, true},
+			{float("101"), 0, false},
+		}},
+		{"max silence heartbeat", deadband{absolute: 1, maxSilence: time.Minute}, []step{
+			{float("20"), 0, true},
+			{float("20"), 30 * time.Second, false},
+			{float("20"), time.Minute, true},
+			{float("20"), 90 * time.Second, false},
+		}},
+		{"non-numeric deduplication", deadband{absolute: 1}, []step{
+			{str("on"), 0, true},
+			{str("on"), 0, false},
+			{str("off"), 0, true},
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &deadbandFilter{reported: make(map[string]map[string]reportedReading)}
+			for i, s := range tt.steps {
+				kept := filter.keep("test-device", s.reading, tt.db, start.Add(s.after))
+				assert.Equal(t, s.expected, kept, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestForgetDeadband(t *testing.T) {
+	reading := dtos.BaseReading{ResourceName: "temperature", ValueType: common.ValueTypeInt32, SimpleReading: dtos.SimpleReading{Value: "20"}}
+	db := deadband{absolute: 5}
+	now := time.Now()
+
+	assert.True(t, deadbands.keep("forget-device", reading, db, now))
+	assert.False(t, deadbands.keep("forget-device", reading, db, now))
+	ForgetDeadband("forget-device")
+	assert.True(t, deadbands.keep("forget-device", reading, db, now))
+}
@@ -0,0 +1,157 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/google/uuid"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const defaultBinaryMediaType = "application/octet-stream"
+
+// limitedReader fails once more than max bytes, unless max is 0, are read from r.
+type limitedReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.max > 0 && l.read > l.max {
+		return n, fmt.Errorf("streamed value exceeds Device.BinaryStream.MaxBytes (%d bytes)", l.max)
+	}
+	return n, err
+}
+
+// streamToReading transfers the value of a CommandValue created by NewStreamCommandValue
+// according to Device.BinaryStream, and returns the Object reading referencing it.
+func streamToReading(cv *models.CommandValue, stream io.Reader, deviceName, profileName, mediaType string, eventOrigin int64, dic *di.Container) (dtos.BaseReading, errors.EdgeX) {
+	info := container.ConfigurationFrom(dic.Get).Device.BinaryStream
+	if closer, ok := stream.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	hash := sha256.New()
+	reader := &limitedReader{r: io.TeeReader(stream, hash), max: info.MaxBytes}
+	transferID := uuid.NewString()
+	ref := sdkDTOs.BinaryReference{MediaType: mediaType}
+	var err error
+	if info.Mode == sdkCommon.BinaryStreamChunked {
+		ref.Location = fmt.Sprintf("%s/%s/%s/%s", info.ChunkTopicPrefix, deviceName, cv.DeviceResourceName, transferID)
+		ref.Chunks, err = publishChunks(reader, ref.Location, transferID, mediaType, info, dic)
+	} else {
+		name := fmt.Sprintf("%s/%s/%s", deviceName, cv.DeviceResourceName, transferID)
+		ref.Location, err = putObject(reader, name, dic)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to transfer the streamed value of DeviceResource %s", cv.DeviceResourceName)
+		return dtos.BaseReading{}, errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
+	}
+	ref.Size = reader.read
+	ref.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	reading := dtos.NewObjectReading(profileName, deviceName, cv.DeviceResourceName, ref)
+	if cv.Origin != 0 {
+		reading.Origin = cv.Origin
+	} else {
+		reading.Origin = eventOrigin
+	}
+	return reading, nil
+}
+
+func putObject(r io.Reader, name string, dic *di.Container) (string, error) {
+	store := container.ObjectStoreFrom(dic.Get)
+	if store == nil {
+		return "", fmt.Errorf("no object store")
+	}
+	location, err := store.Put(name, r)
+	if err != nil {
+		// the store may keep a partial object when the stream fails
+		_ = store.Delete(name)
+		return "", err
+	}
+	return location, nil
+}
+
+// publishChunks publishes the value read from r to the topics location/0, location/1, ...
+// in messages of Device.BinaryStream.ChunkSize bytes, and returns the number of chunks.
+func publishChunks(r io.Reader, location string, transferID string, mediaType string, info config.BinaryStreamInfo, dic *di.Container) (int, error) {
+	mc := container.MessagingClientFrom(dic.Get)
+	if mc == nil {
+		return 0, fmt.Errorf("chunked transfer requires Device.UseMessageBus")
+	}
+	if mediaType == "" {
+		mediaType = defaultBinaryMediaType
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	chunks := 0
+	for {
+		chunk := make([]byte, info.ChunkSize)
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			envelope := types.MessageEnvelope{
+				CorrelationID: transferID,
+				Payload:       chunk[:n],
+				ContentType:   mediaType,
+			}
+			if pubErr := mc.Publish(envelope, fmt.Sprintf("%s/%d", location, chunks)); pubErr != nil {
+				return chunks, fmt.Errorf("failed to publish chunk %d: %v", chunks, pubErr)
+			}
+			chunks++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			lc.Debugf("Streamed value published in %d chunks to %s", chunks, location)
+			return chunks, nil
+		}
+		if err != nil {
+			return chunks, err
+		}
+	}
+}
+
+// ValidateBinaryStream checks Device.BinaryStream.
+func ValidateBinaryStream(dic *di.Container) errors.EdgeX {
+	configuration := container.ConfigurationFrom(dic.Get)
+	info := configuration.Device.BinaryStream
+	switch info.Mode {
+	case "", sdkCommon.BinaryStreamObjectStore:
+	case sdkCommon.BinaryStreamChunked:
+		if !configuration.Device.UseMessageBus {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "Device.BinaryStream.Mode Chunked requires Device.UseMessageBus", nil)
+		}
+		if info.ChunkSize <= 0 {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.BinaryStream.ChunkSize %d", info.ChunkSize), nil)
+		}
+	default:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.BinaryStream.Mode %s", info.Mode), nil)
+	}
+	if info.Retention != "" {
+		if retention, err := time.ParseDuration(info.Retention); err != nil || retention <= 0 {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.BinaryStream.Retention %s", info.Retention), err)
+		}
+	}
+	return nil
+}
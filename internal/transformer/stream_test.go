@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-messaging/v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/objectstore"
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// chunkClient is a MessageClient capturing the published chunks by topic.
+type chunkClient struct {
+	topics   []string
+	payloads [][]byte
+}
+
+func (c *chunkClient) Connect() error {
+	return nil
+}
+
+func (c *chunkClient) Publish(message types.MessageEnvelope, topic string) error {
+	c.topics = append(c.topics, topic)
+	c.payloads = append(c.payloads, message.Payload)
+	return nil
+}
+
+func (c *chunkClient) Subscribe(_ []types.TopicChannel, _ chan error) error {
+	return nil
+}
+
+func (c *chunkClient) Disconnect() error {
+	return nil
+}
+
+// closeRecorder is a stream recording whether it is closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func streamDic(t *testing.T, info config.BinaryStreamInfo, mc *chunkClient) *di.Container {
+	store, err := objectstore.NewFileStore(info.Path)
+	require.NoError(t, err)
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Device: config.DeviceInfo{UseMessageBus: true, BinaryStream: info}}
+		},
+		container.ObjectStoreName: func(get di.Get) interface{} {
+			return store
+		},
+		container.MessagingClientName: func(get di.Get) interface{} {
+			return mc
+		},
+	})
+}
+
+func Test_streamToReading(t *testing.T) {
+	value := bytes.Repeat([]byte("frame"), 5)
+	sum := sha256.Sum256(value)
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("ObjectStore", func(t *testing.T) {
+		dir := t.TempDir()
+		dic := streamDic(t, config.BinaryStreamInfo{Mode: sdkCommon.BinaryStreamObjectStore, Path: dir}, nil)
+		stream := &closeRecorder{Reader: bytes.NewReader(value)}
+		cv := models.NewStreamCommandValue("camera", stream)
+
+		reading, err := streamToReading(cv, stream, "test-device", "test-profile", "image/jpeg", 1, dic)
+		require.NoError(t, err)
+		assert.True(t, stream.closed)
+		assert.Equal(t, common.ValueTypeObject, reading.ValueType)
+		assert.Equal(t, int64(1), reading.Origin)
+		ref, ok := reading.ObjectValue.(sdkDTOs.BinaryReference)
+		require.True(t, ok)
+		assert.Equal(t, int64(len(value)), ref.Size)
+		assert.Equal(t, checksum, ref.Checksum)
+		assert.Equal(t, "image/jpeg", ref.MediaType)
+		assert.Zero(t, ref.Chunks)
+
+		u, parseErr := url.Parse(ref.Location)
+		require.NoError(t, parseErr)
+		assert.Equal(t, filepath.Join(dir, "test-device", "camera"), filepath.Dir(filepath.FromSlash(u.Path)))
+		stored, readErr := os.ReadFile(filepath.FromSlash(u.Path))
+		require.NoError(t, readErr)
+		assert.Equal(t, value, stored)
+	})
+
+	t.Run("Chunked", func(t *testing.T) {
+		mc := &chunkClient{}
+		dic := streamDic(t, config.BinaryStreamInfo{Mode: sdkCommon.BinaryStreamChunked, ChunkSize: 10, ChunkTopicPrefix: "edgex/binary"}, mc)
+		cv := models.NewStreamCommandValue("camera", bytes.NewReader(value))
+		stream, _ := cv.BinaryStream()
+
+		reading, err := streamToReading(cv, stream, "test-device", "test-profile", "", 1, dic)
+		require.NoError(t, err)
+		ref, ok := reading.ObjectValue.(sdkDTOs.BinaryReference)
+		require.True(t, ok)
+		assert.Equal(t, 3, ref.Chunks)
+		assert.Equal(t, int64(len(value)), ref.Size)
+		assert.Equal(t, checksum, ref.Checksum)
+		assert.Equal(t, []string{ref.Location + "/0", ref.Location + "/1", ref.Location + "/2"}, mc.topics)
+		assert.Equal(t, value, bytes.Join(mc.payloads, nil))
+	})
+
+	t.Run("MaxBytes exceeded", func(t *testing.T) {
+		dir := t.TempDir()
+		dic := streamDic(t, config.BinaryStreamInfo{Path: dir, MaxBytes: 10}, nil)
+		cv := models.NewStreamCommandValue("camera", bytes.NewReader(value))
+		stream, _ := cv.BinaryStream()
+
+		_, err := streamToReading(cv, stream, "test-device", "test-profile", "", 1, dic)
+		assert.Error(t, err)
+		entries, readErr := os.ReadDir(filepath.Join(dir, "test-device", "camera"))
+		require.NoError(t, readErr)
+		assert.Empty(t, entries, "no partial object is kept")
+	})
+}
+
+func TestValidateBinaryStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		useMessageBus bool
+		info          config.BinaryStreamInfo
+		expectedError bool
+	}{
+		{"valid - default", false, config.BinaryStreamInfo{}, false},
+		{"valid - Chunked", true, config.BinaryStreamInfo{Mode: sdkCommon.BinaryStreamChunked, ChunkSize: 1024}, false},
+		{"invalid - Chunked without MessageBus", false, config.BinaryStreamInfo{Mode: sdkCommon.BinaryStreamChunked, ChunkSize: 1024}, true},
+		{"invalid - ChunkSize", true, config.BinaryStreamInfo{Mode: sdkCommon.BinaryStreamChunked}, true},
+		{"invalid - Mode", false, config.BinaryStreamInfo{Mode: "Inline"}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{Device: config.DeviceInfo{UseMessageBus: tt.useMessageBus, BinaryStream: tt.info}}
+				},
+			})
+			err := ValidateBinaryStream(dic)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
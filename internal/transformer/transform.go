@@ -105,7 +105,12 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 			tags[key] = value
 		}
 
-		reading, err := commandValueToReading(cv, device.Name, device.ProfileName, dr.Properties.MediaType, origin)
+		var reading dtos.BaseReading
+		if stream, ok := cv.BinaryStream(); ok {
+			reading, err = streamToReading(cv, stream, device.Name, device.ProfileName, dr.Properties.MediaType, origin, dic)
+		} else {
+			reading, err = commandValueToReading(cv, device.Name, device.ProfileName, dr.Properties.MediaType, origin)
+		}
 		if err != nil {
 			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
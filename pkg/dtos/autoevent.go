@@ -0,0 +1,39 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// AutoEventStatus describes the runtime state of the AutoEvent of a device source.
+// This object and its properties correspond to the AutoEventStatus object in the APIv2 specification.
+type AutoEventStatus struct {
+	DeviceName string `json:"deviceName"`
+	SourceName string `json:"sourceName"`
+	// Interval is the schedule of the AutoEvent, a duration or a schedule expression
+	Interval string `json:"interval"`
+	// CurrentInterval is the interval of an adaptive AutoEvent, which varies between the
+	// ds-mininterval and ds-maxinterval of the device
+	CurrentInterval string `json:"currentInterval,omitempty"`
+	OnChange        bool   `json:"onChange"`
+	Paused          bool   `json:"paused"`
+	// Running indicates a reading of the AutoEvent is in progress
+	Running bool `json:"running"`
+	// LastRun is the start time of the last reading in nanoseconds since the epoch
+	LastRun int64 `json:"lastRun,omitempty"`
+	// LastDuration is the duration of the last reading as a duration string
+	LastDuration string `json:"lastDuration,omitempty"`
+	// LastError is the error of the last reading, if it failed
+	LastError string `json:"lastError,omitempty"`
+	// NextRun is the next scheduled reading in nanoseconds since the epoch
+	NextRun int64 `json:"nextRun,omitempty"`
+	Runs    int64 `json:"runs"`
+	Errors  int64 `json:"errors"`
+	// EventsSent is the number of events sent, EventsSuppressed the number of readings which
+	// sent no event, being unchanged or within the deadband of their device resources
+	EventsSent       int64 `json:"eventsSent"`
+	EventsSuppressed int64 `json:"eventsSuppressed"`
+	// MissedTicks is the number of fire times missed while a reading was in progress
+	MissedTicks int64 `json:"missedTicks"`
+}
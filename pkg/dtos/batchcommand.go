@@ -0,0 +1,36 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+// BatchCommand describes one device command of a batch command request.
+// This object and its properties correspond to the BatchCommand object in the APIv2 specification.
+type BatchCommand struct {
+	DeviceName  string `json:"deviceName" validate:"required"`
+	CommandName string `json:"commandName" validate:"required"`
+	// Method is GET to read the command or PUT to write it with Parameters
+	Method     string                 `json:"method" validate:"oneof='GET' 'PUT'"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// QueryParams are the query parameters of the equivalent single command request,
+	// including the SDK reserved ones such as ds-pushevent and ds-returnevent
+	QueryParams map[string]string `json:"queryParams,omitempty"`
+}
+
+// BatchCommandResult describes the outcome of one device command of a batch command request.
+// This object and its properties correspond to the BatchCommandResult object in the APIv2 specification.
+type BatchCommandResult struct {
+	DeviceName      string                    `json:"deviceName"`
+	CommandName     string                    `json:"commandName"`
+	StatusCode      int                       `json:"statusCode"`
+	Message         string                    `json:"message,omitempty"`
+	Event           *dtos.Event               `json:"event,omitempty"`
+	FailedResources []FailedResource          `json:"failedResources,omitempty"`
+	Operations      []ResourceOperationResult `json:"operations,omitempty"`
+}
@@ -0,0 +1,20 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// BinaryReference is the value of the Object reading emitted in place of a binary reading
+// for a value streamed by the ProtocolDriver. The value is either stored in the object store,
+// or published in Chunks MessageBus messages to the topics Location/0 to Location/Chunks-1.
+// This object and its properties correspond to the BinaryReference object in the APIv2 specification.
+type BinaryReference struct {
+	Location  string `json:"location"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"mediaType,omitempty"`
+	// Checksum is the hex encoded SHA-256 of the value
+	Checksum string `json:"checksum"`
+	Chunks   int    `json:"chunks,omitempty"`
+}
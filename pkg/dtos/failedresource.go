@@ -0,0 +1,15 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// FailedResource describes a device resource which failed to be read by a partially
+// successful GET command.
+// This object and its properties correspond to the FailedResource object in the APIv2 specification.
+type FailedResource struct {
+	DeviceResource string `json:"deviceResource"`
+	Message        string `json:"message"`
+}
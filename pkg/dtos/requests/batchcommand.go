@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// BatchCommandRequest defines the Request Content for executing the device commands of
+// many devices in one request.
+// This object and its properties correspond to the BatchCommandRequest object in the APIv2 specification.
+type BatchCommandRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	Commands              []dtos.BatchCommand `json:"commands" validate:"gt=0,dive"`
+}
+
+// Validate satisfies the Validator interface
+func (r BatchCommandRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the BatchCommandRequest type
+func (r *BatchCommandRequest) UnmarshalJSON(b []byte) error {
+	alias := struct {
+		dtoCommon.BaseRequest
+		Commands []dtos.BatchCommand
+	}{}
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+	*r = BatchCommandRequest(alias)
+
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
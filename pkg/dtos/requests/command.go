@@ -0,0 +1,62 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// CommandRequest defines the Request Content for executing a device command received from
+// the MessageBus. The response is published to a topic ending with the RequestId.
+// This object and its properties correspond to the CommandRequest object in the APIv2 specification.
+type CommandRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	DeviceName            string `json:"deviceName" validate:"required"`
+	CommandName           string `json:"commandName" validate:"required"`
+	// Method is GET to read the command or PUT to write it with Parameters
+	Method     string                 `json:"method" validate:"oneof='GET' 'PUT'"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// QueryParams are the query parameters of the equivalent REST request, including the
+	// SDK reserved ones such as ds-pushevent and ds-returnevent
+	QueryParams map[string]string `json:"queryParams,omitempty"`
+}
+
+// Validate satisfies the Validator interface
+func (r CommandRequest) Validate() error {
+	if r.RequestId == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "requestId is required to publish the response", nil)
+	}
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the CommandRequest type
+func (r *CommandRequest) UnmarshalJSON(b []byte) error {
+	alias := struct {
+		dtoCommon.BaseRequest
+		DeviceName  string
+		CommandName string
+		Method      string
+		Parameters  map[string]interface{}
+		QueryParams map[string]string
+	}{}
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+	*r = CommandRequest(alias)
+
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
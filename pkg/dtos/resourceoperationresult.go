@@ -0,0 +1,25 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// Statuses of a ResourceOperationResult
+const (
+	OperationSucceeded      = "Succeeded"
+	OperationFailed         = "Failed"
+	OperationSkipped        = "Skipped"
+	OperationRolledBack     = "RolledBack"
+	OperationRollbackFailed = "RollbackFailed"
+)
+
+// ResourceOperationResult describes the outcome of writing one device resource of a
+// transactional SET command.
+// This object and its properties correspond to the ResourceOperationResult object in the APIv2 specification.
+type ResourceOperationResult struct {
+	DeviceResource string `json:"deviceResource"`
+	Status         string `json:"status"`
+	Message        string `json:"message,omitempty"`
+}
@@ -0,0 +1,27 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// MultiAutoEventStatusResponse defines the Response Content for the AutoEvent status requests.
+// This object and its properties correspond to the MultiAutoEventStatusResponse object in the APIv2 specification.
+type MultiAutoEventStatusResponse struct {
+	dtoCommon.BaseResponse `json:",inline"`
+	AutoEvents             []dtos.AutoEventStatus `json:"autoEvents"`
+}
+
+func NewMultiAutoEventStatusResponse(requestId string, message string, statusCode int, autoEvents []dtos.AutoEventStatus) MultiAutoEventStatusResponse {
+	return MultiAutoEventStatusResponse{
+		BaseResponse: dtoCommon.NewBaseResponse(requestId, message, statusCode),
+		AutoEvents:   autoEvents,
+	}
+}
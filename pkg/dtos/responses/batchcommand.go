@@ -0,0 +1,28 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// BatchCommandResponse defines the Response Content for batch command requests, with one
+// result per requested command in the request order.
+// This object and its properties correspond to the BatchCommandResponse object in the APIv2 specification.
+type BatchCommandResponse struct {
+	dtoCommon.BaseResponse `json:",inline"`
+	Results                []dtos.BatchCommandResult `json:"results"`
+}
+
+func NewBatchCommandResponse(requestId string, message string, statusCode int, results []dtos.BatchCommandResult) BatchCommandResponse {
+	return BatchCommandResponse{
+		BaseResponse: dtoCommon.NewBaseResponse(requestId, message, statusCode),
+		Results:      results,
+	}
+}
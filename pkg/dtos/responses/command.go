@@ -0,0 +1,29 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+
+	sdkDTOs "github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// CommandResponse defines the Response Content for device commands received from the MessageBus.
+// This object and its properties correspond to the CommandResponse object in the APIv2 specification.
+type CommandResponse struct {
+	dtoCommon.BaseResponse `json:",inline"`
+	Event                  *dtos.Event                       `json:"event,omitempty"`
+	FailedResources        []sdkDTOs.FailedResource          `json:"failedResources,omitempty"`
+	Operations             []sdkDTOs.ResourceOperationResult `json:"operations,omitempty"`
+}
+
+func NewCommandResponse(requestId string, message string, statusCode int) CommandResponse {
+	return CommandResponse{
+		BaseResponse: dtoCommon.NewBaseResponse(requestId, message, statusCode),
+	}
+}
@@ -0,0 +1,26 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+)
+
+// MetricsResponse extends the common MetricsResponse with the counters and gauges of the device
+// service, such as the depth of the event store.
+// This object and its properties correspond to the MetricsResponse object in the APIv2 specification.
+type MetricsResponse struct {
+	dtoCommon.MetricsResponse `json:",inline"`
+	ServiceMetrics            map[string]int64 `json:"serviceMetrics,omitempty"`
+}
+
+func NewMetricsResponse(metrics dtoCommon.Metrics, serviceName string, serviceMetrics map[string]int64) MetricsResponse {
+	return MetricsResponse{
+		MetricsResponse: dtoCommon.NewMetricsResponse(metrics, serviceName),
+		ServiceMetrics:  serviceMetrics,
+	}
+}
@@ -0,0 +1,70 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	coreDTOs "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// PartialEventResponse defines the Response Content for GET commands of which some device
+// resources failed to be read. The Event holds the readings of the other device resources.
+// This object and its properties correspond to the PartialEventResponse object in the APIv2 specification.
+type PartialEventResponse struct {
+	dtoCommon.BaseResponse `json:",inline"`
+	Event                  coreDTOs.Event        `json:"event"`
+	FailedResources        []dtos.FailedResource `json:"failedResources"`
+}
+
+func NewPartialEventResponse(requestId string, message string, statusCode int, event coreDTOs.Event, failedResources []dtos.FailedResource) PartialEventResponse {
+	return PartialEventResponse{
+		BaseResponse:    dtoCommon.NewBaseResponse(requestId, message, statusCode),
+		Event:           event,
+		FailedResources: failedResources,
+	}
+}
+
+// Encode encodes the response to CBOR if the event holds binary readings, like EventResponse,
+// or to JSON otherwise.
+func (e *PartialEventResponse) Encode() ([]byte, string, error) {
+	var encoding = common.ContentTypeJSON
+
+	for _, r := range e.Event.Readings {
+		if r.ValueType == common.ValueTypeBinary {
+			encoding = common.ContentTypeCBOR
+			break
+		}
+	}
+	if v := os.Getenv(common.EnvEncodeAllEvents); v == common.ValueTrue {
+		encoding = common.ContentTypeCBOR
+	}
+
+	var err error
+	var encodedData []byte
+	switch encoding {
+	case common.ContentTypeCBOR:
+		encodedData, err = cbor.Marshal(e)
+		if err != nil {
+			return nil, "", errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode PartialEventResponse to CBOR", err)
+		}
+	case common.ContentTypeJSON:
+		encodedData, err = json.Marshal(e)
+		if err != nil {
+			return nil, "", errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode PartialEventResponse to JSON", err)
+		}
+	}
+
+	return encodedData, encoding, nil
+}
@@ -0,0 +1,27 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
+// WriteTransactionResponse defines the Response Content for transactional SET commands.
+// This object and its properties correspond to the WriteTransactionResponse object in the APIv2 specification.
+type WriteTransactionResponse struct {
+	dtoCommon.BaseResponse `json:",inline"`
+	Operations             []dtos.ResourceOperationResult `json:"operations"`
+}
+
+func NewWriteTransactionResponse(requestId string, message string, statusCode int, operations []dtos.ResourceOperationResult) WriteTransactionResponse {
+	return WriteTransactionResponse{
+		BaseResponse: dtoCommon.NewBaseResponse(requestId, message, statusCode),
+		Operations:   operations,
+	}
+}
@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2018 IOTech Ltd
+// Copyright (C) 2018-2022 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -11,4 +11,9 @@ type AsyncValues struct {
 	DeviceName    string
 	SourceName    string
 	CommandValues []*CommandValue
+	// OnPublished, if set, is called once with the outcome of publishing the event of the
+	// readings: nil once published, ErrEventStored once stored for replay, ErrEventFiltered
+	// when the readings are within deadband, or the error which prevented the event to be
+	// published.
+	OnPublished func(err error)
 }
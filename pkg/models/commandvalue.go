@@ -10,6 +10,7 @@ package models
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
@@ -55,6 +56,19 @@ func NewCommandValue(deviceResourceName string, valueType string, value interfac
 		Tags:               make(map[string]string)}, nil
 }
 
+// NewStreamCommandValue creates a Binary CommandValue whose value is read from reader, for
+// payloads too large to be buffered, e.g. camera frames. Instead of a binary reading, the SDK
+// streams the value to the object store or in chunks across MessageBus messages, see
+// Device.BinaryStream, and emits a reading holding a BinaryReference to it. The reader is
+// closed once read if it implements io.Closer.
+func NewStreamCommandValue(deviceResourceName string, reader io.Reader) *CommandValue {
+	return &CommandValue{
+		DeviceResourceName: deviceResourceName,
+		Type:               common.ValueTypeBinary,
+		Value:              reader,
+		Tags:               make(map[string]string)}
+}
+
 // NewCommandValueWithOrigin wraps NewCommandValue, create a CommandValue and add the Origin field.
 func NewCommandValueWithOrigin(deviceResourceName string, valueType string, value interface{}, origin int64) (*CommandValue, error) {
 	cv, err := NewCommandValue(deviceResourceName, valueType, value)
@@ -69,8 +83,14 @@ func NewCommandValueWithOrigin(deviceResourceName string, valueType string, valu
 // ValueToString returns the string format of the value.
 func (cv *CommandValue) ValueToString() string {
 	if cv.Type == common.ValueTypeBinary {
+		if _, ok := cv.BinaryStream(); ok {
+			return "Binary: [stream]"
+		}
 		binaryValue := cv.Value.([]byte)
-		return fmt.Sprintf("Binary: [%v...]", string(binaryValue[:20]))
+		if len(binaryValue) > 20 {
+			binaryValue = binaryValue[:20]
+		}
+		return fmt.Sprintf("Binary: [%v...]", string(binaryValue))
 	}
 	return fmt.Sprintf("%v", cv.Value)
 }
@@ -455,6 +475,15 @@ func (cv *CommandValue) BinaryValue() ([]byte, error) {
 	return value, nil
 }
 
+// BinaryStream returns the reader of a CommandValue created by NewStreamCommandValue.
+func (cv *CommandValue) BinaryStream() (io.Reader, bool) {
+	if cv.Type != common.ValueTypeBinary {
+		return nil, false
+	}
+	reader, ok := cv.Value.(io.Reader)
+	return reader, ok
+}
+
 // ObjectValue returns the value in object data type, and returns error if the Type is not Object.
 func (cv *CommandValue) ObjectValue() (interface{}, error) {
 	if cv.Type != common.ValueTypeObject {
@@ -0,0 +1,31 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"context"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// ContextualProtocolDriver is a low-level device-specific interface implemented
+// by device services whose read and write operations honor a context.Context.
+// When implemented, the SDK invokes these methods instead of the ProtocolDriver
+// HandleReadCommands and HandleWriteCommands. The given context carries the
+// correlation ID of the request and is cancelled when the command deadline passes
+// or the requesting client goes away.
+type ContextualProtocolDriver interface {
+	// HandleReadCommandsWithContext passes a slice of CommandRequest struct each representing
+	// a ResourceOperation for a specific device resource.
+	HandleReadCommandsWithContext(ctx context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []CommandRequest) ([]*CommandValue, error)
+
+	// HandleWriteCommandsWithContext passes a slice of CommandRequest struct each representing
+	// a ResourceOperation for a specific device resource.
+	// Since the commands are actuation commands, params provide parameters for the individual
+	// command.
+	HandleWriteCommandsWithContext(ctx context.Context, deviceName string, protocols map[string]models.ProtocolProperties, reqs []CommandRequest, params []*CommandValue) error
+}
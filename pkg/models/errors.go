@@ -0,0 +1,98 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrEventStored is the outcome reported to AsyncValues.OnPublished for an event which
+// failed to be published and is stored for replay by Device.StoreAndForward.
+var ErrEventStored = errors.New("event stored for replay")
+
+// ErrEventFiltered is the outcome reported to AsyncValues.OnPublished when all the readings
+// are within the deadband of their device resource, or when the event does not pass the
+// filter of DeviceService.SetPublishFilter, so that no event is published.
+var ErrEventFiltered = errors.New("event filtered out")
+
+// classifiedError is an error returned by a ProtocolDriver which is marked as
+// permanent or transient for the retry policy of the SDK.
+type classifiedError struct {
+	err       error
+	transient bool
+}
+
+func (e classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e classifiedError) Unwrap() error {
+	return e.err
+}
+
+// NewPermanentError marks err as permanent, e.g. an invalid register address, so that
+// the SDK never retries the failed read or write command.
+func NewPermanentError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return classifiedError{err: err}
+}
+
+// NewTransientError marks err as transient, e.g. a bus timeout, so that the SDK retries
+// the failed read command, or write command if the retries of writes are enabled,
+// regardless of the retryable error kinds configured.
+func NewTransientError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return classifiedError{err: err, transient: true}
+}
+
+// IsPermanentError reports whether err, or any error it wraps, is marked as permanent.
+func IsPermanentError(err error) bool {
+	var e classifiedError
+	return errors.As(err, &e) && !e.transient
+}
+
+// IsTransientError reports whether err, or any error it wraps, is marked as transient.
+func IsTransientError(err error) bool {
+	var e classifiedError
+	return errors.As(err, &e) && e.transient
+}
+
+// PartialReadError is returned by HandleReadCommands along with the CommandValues of the
+// requests which succeeded when only some of the requests failed. The SDK then emits an
+// event with the successful readings and reports the failed device resources.
+type PartialReadError struct {
+	// Errors holds the error of each failed request keyed by device resource name.
+	Errors map[string]error
+}
+
+// NewPartialReadError returns a PartialReadError for the failed requests, or nil if none failed.
+func NewPartialReadError(errs map[string]error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return PartialReadError{Errors: errs}
+}
+
+func (e PartialReadError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, e.Errors[name])
+	}
+	return fmt.Sprintf("failed to read %d device resources: %s", len(names), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,51 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorClassification(t *testing.T) {
+	cause := errors.New("failure")
+	tests := []struct {
+		name              string
+		err               error
+		expectedPermanent bool
+		expectedTransient bool
+	}{
+		{"unclassified", cause, false, false},
+		{"permanent", NewPermanentError(cause), true, false},
+		{"transient", NewTransientError(cause), false, true},
+		{"wrapped permanent", fmt.Errorf("read failed: %w", NewPermanentError(cause)), true, false},
+		{"wrapped transient", fmt.Errorf("read failed: %w", NewTransientError(cause)), false, true},
+		{"nil", NewTransientError(nil), false, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedPermanent, IsPermanentError(testCase.err))
+			assert.Equal(t, testCase.expectedTransient, IsTransientError(testCase.err))
+			if testCase.err != nil {
+				assert.ErrorIs(t, testCase.err, cause)
+			}
+		})
+	}
+}
+
+func TestPartialReadError(t *testing.T) {
+	assert.Nil(t, NewPartialReadError(nil))
+
+	err := NewPartialReadError(map[string]error{"r2": errors.New("timeout"), "r1": errors.New("bad address")})
+	var partial PartialReadError
+	assert.True(t, errors.As(fmt.Errorf("read failed: %w", err), &partial))
+	assert.Len(t, partial.Errors, 2)
+	assert.Equal(t, "failed to read 2 device resources: r1: bad address; r2: timeout", err.Error())
+}
@@ -0,0 +1,47 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+
+// EventSink receives the events of the device service in addition to core-data or the
+// MessageBus, e.g. to keep them in a local file or to forward them to another system. The
+// device service registers its sinks with DeviceService.AddEventSink.
+type EventSink interface {
+	// Name identifies the sink in the logs.
+	Name() string
+	// Send delivers the event to the sink. It is called from a goroutine of its own for each
+	// sink once the event is published, the events waiting beyond a short queue being dropped.
+	Send(event dtos.Event, correlationID string) error
+}
+
+// EventSinkFilter selects the events sent to an EventSink by their device, profile or source
+// name. An empty list matches any name.
+type EventSinkFilter struct {
+	DeviceNames  []string
+	ProfileNames []string
+	SourceNames  []string
+}
+
+// Match indicates whether the event passes the filter.
+func (f EventSinkFilter) Match(event dtos.Event) bool {
+	return matchName(f.DeviceNames, event.DeviceName) &&
+		matchName(f.ProfileNames, event.ProfileName) &&
+		matchName(f.SourceNames, event.SourceName)
+}
+
+func matchName(names []string, name string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+
+// StoredEvent is an event which failed to be published, kept in an EventStore until it
+// is replayed.
+type StoredEvent struct {
+	Event         dtos.Event
+	CorrelationID string
+	// Stored is the time in nanoseconds at which the event was stored.
+	Stored int64
+}
+
+// EventStore is a persistent FIFO queue of the events which failed to be published to
+// core-data or the MessageBus. When Device.StoreAndForward is enabled, the SDK replays the
+// stored events in order once publishing succeeds again. A file-backed EventStore is used
+// unless the device service provides its own with DeviceService.SetEventStore.
+type EventStore interface {
+	// Append adds the event at the tail of the queue.
+	Append(event StoredEvent) error
+	// Peek returns up to limit events from the head of the queue without removing them.
+	Peek(limit int) ([]StoredEvent, error)
+	// Remove removes count events from the head of the queue.
+	Remove(count int) error
+	// Len returns the number of events in the queue.
+	Len() (int, error)
+}
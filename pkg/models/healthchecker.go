@@ -0,0 +1,18 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+// HealthChecker is a low-level device-specific interface implemented by device
+// services which can probe whether a device is reachable. When implemented, the
+// SDK uses it to decide when a device marked as DOWN after consecutive command
+// failures can be restored to UP.
+type HealthChecker interface {
+	// CheckHealth probes the device and returns error if it is still unreachable.
+	CheckHealth(deviceName string, protocols map[string]models.ProtocolProperties) error
+}
@@ -5,6 +5,12 @@
 
 package models
 
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/dtos"
+)
+
 type AutoEventManager interface {
 	// StartAutoEvents starts all the AutoEvents of the device service
 	StartAutoEvents()
@@ -13,3 +19,23 @@ type AutoEventManager interface {
 	// StopForDevice stops all the AutoEvents of the specific device
 	StopForDevice(name string)
 }
+
+// AutoEventController controls the AutoEvents of a device source one at a time. The
+// AutoEventManager of the SDK implements it, the status and control of the AutoEvents being
+// unavailable with an AutoEventManager that does not.
+type AutoEventController interface {
+	// AutoEventStatuses returns the status of the AutoEvents of the specific device, or of
+	// all the devices if name is empty
+	AutoEventStatuses(name string) ([]dtos.AutoEventStatus, errors.EdgeX)
+	// PauseAutoEvent stops the scheduled readings of the AutoEvents of the device source
+	// until they are resumed, including after the AutoEvents of the device are restarted
+	PauseAutoEvent(name string, sourceName string) errors.EdgeX
+	// ResumeAutoEvent resumes the scheduled readings of the AutoEvents of the device source
+	ResumeAutoEvent(name string, sourceName string) errors.EdgeX
+	// TriggerAutoEvent starts a reading of the AutoEvents of the device source immediately,
+	// even if they are paused
+	TriggerAutoEvent(name string, sourceName string) errors.EdgeX
+	// ForgetDevice forgets the paused AutoEvents of the specific device, which is deleted, so
+	// that a device added later with the same name starts with none paused
+	ForgetDevice(name string)
+}
@@ -0,0 +1,21 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "io"
+
+// ObjectStore keeps the binary values streamed by the ProtocolDriver with
+// NewStreamCommandValue, which are referenced by the readings instead of being embedded
+// in them. A local filesystem ObjectStore is used unless the device service provides its
+// own with DeviceService.SetObjectStore.
+type ObjectStore interface {
+	// Put stores the content read from r under name, which is made of slash-separated
+	// path elements, and returns the location of the object, e.g. a URL.
+	Put(name string, r io.Reader) (location string, err error)
+	// Delete removes the object stored under name, if any.
+	Delete(name string) error
+}
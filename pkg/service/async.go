@@ -9,6 +9,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"sync"
 
@@ -32,37 +33,86 @@ import (
 // out-of-order in core-data / app service when AsyncBufferSize value
 // is greater than or equal to two. Alternatively, we can process
 // AsyncValues one by one in the same order by changing the AsyncBufferSize
-// value to one.
+// value to one, or keep the AsyncValues of each device in order with
+// Device.AsyncOrdering.
 func (s *DeviceService) processAsyncResults(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
 	wg.Add(1)
 	defer func() {
 		wg.Done()
 	}()
 
-	working := make(chan bool, s.config.Device.AsyncBufferSize)
+	if s.asyncDispatcher != nil {
+		s.processOrderedAsyncResults(ctx, dic)
+		return
+	}
+
+	// a working slot is taken before the next AsyncValues is received, so that the AsyncValues
+	// in excess wait in the queue where Device.AsyncOverflow.Policy applies
+	size := s.config.Device.AsyncBufferSize
+	if size < 1 {
+		size = 1
+	}
+	working := make(chan bool, size)
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case acv := <-s.asyncCh:
+		case working <- true:
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case acv := <-s.asyncQueue.ch:
 			go s.sendAsyncValues(acv, working, dic)
 		}
 	}
 }
 
-// sendAsyncValues convert AsyncValues to event and send the event to CoreData
+// processOrderedAsyncResults hands the AsyncValues to the workers of the asyncDispatcher,
+// which publish the events of the same device in the order the AsyncValues were pushed.
+func (s *DeviceService) processOrderedAsyncResults(ctx context.Context, dic *di.Container) {
+	var workers sync.WaitGroup
+	s.asyncDispatcher.start(&workers, func(acv *sdkModels.AsyncValues) {
+		s.publishAsyncValues(acv, dic)
+	})
+	defer func() {
+		s.asyncDispatcher.stop()
+		workers.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case acv := <-s.asyncQueue.ch:
+			if !s.asyncDispatcher.dispatch(ctx, acv) && ctx.Err() == nil {
+				s.LoggingClient.Warnf("Dropped AsyncValues of device %s because its queue is full", acv.DeviceName)
+				reportAsyncOutcome(acv, errors.New("async queue full"))
+			}
+		}
+	}
+}
+
+// sendAsyncValues convert AsyncValues to event and send the event to CoreData, and then
+// releases the working slot taken for it
 func (s *DeviceService) sendAsyncValues(acv *sdkModels.AsyncValues, working chan bool, dic *di.Container) {
-	working <- true
 	defer func() {
 		<-working
 	}()
 
+	s.publishAsyncValues(acv, dic)
+}
+
+// publishAsyncValues converts the AsyncValues to an event and publishes it.
+func (s *DeviceService) publishAsyncValues(acv *sdkModels.AsyncValues, dic *di.Container) {
 	if len(acv.CommandValues) == 0 {
 		s.LoggingClient.Error("Skip sending AsyncValues because the CommandValues is empty.")
+		reportAsyncOutcome(acv, errors.New("empty CommandValues"))
 		return
 	}
 	if len(acv.CommandValues) > 1 && acv.SourceName == "" {
 		s.LoggingClient.Error("Skip sending AsyncValues because the SourceName is empty.")
+		reportAsyncOutcome(acv, errors.New("empty SourceName"))
 		return
 	}
 	// We can use the first reading's DeviceResourceName as the SourceName
@@ -73,10 +123,23 @@ func (s *DeviceService) sendAsyncValues(acv *sdkModels.AsyncValues, working chan
 	event, err := transformer.CommandValuesToEventDTO(acv.CommandValues, acv.DeviceName, acv.SourceName, dic)
 	if err != nil {
 		s.LoggingClient.Errorf("failed to transform CommandValues to Event: %v", err)
+		reportAsyncOutcome(acv, err)
+		return
+	}
+	if event = transformer.FilterEvent(event); event == nil {
+		s.LoggingClient.Debugf("Skip sending AsyncValues of device %s because the readings are within deadband", acv.DeviceName)
+		reportAsyncOutcome(acv, sdkModels.ErrEventFiltered)
 		return
 	}
 
-	common.SendEvent(event, "", dic)
+	common.SendEventWithOutcome(event, "", dic, acv.OnPublished)
+}
+
+// reportAsyncOutcome reports to the driver the AsyncValues which cannot be published.
+func reportAsyncOutcome(acv *sdkModels.AsyncValues, err error) {
+	if acv.OnPublished != nil {
+		acv.OnPublished(err)
+	}
 }
 
 // processAsyncFilterAndAdd filter and add devices discovered by
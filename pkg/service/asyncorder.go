@@ -0,0 +1,127 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// Device.AsyncOrdering.OrderBy values
+const (
+	orderByDevice       = "Device"
+	orderByDeviceSource = "DeviceSource"
+)
+
+// Device.AsyncOrdering.FullPolicy values
+const (
+	fullPolicyBlock = "Block"
+	fullPolicyDrop  = "Drop"
+)
+
+var droppedAsyncValues = telemetry.NewMetric("AsyncValuesDropped")
+
+// orderedDispatcher processes the AsyncValues of the same key, which is the device name or
+// the device and source names, in order by always handing them to the same worker, while
+// the AsyncValues of different keys are processed in parallel by the other workers.
+type orderedDispatcher struct {
+	bySource bool
+	drop     bool
+	queues   []chan *sdkModels.AsyncValues
+}
+
+func newOrderedDispatcher(info config.AsyncOrderingInfo) (*orderedDispatcher, errors.EdgeX) {
+	d := &orderedDispatcher{}
+	switch info.OrderBy {
+	case orderByDevice:
+	case orderByDeviceSource:
+		d.bySource = true
+	default:
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.AsyncOrdering.OrderBy %s", info.OrderBy), nil)
+	}
+	switch info.FullPolicy {
+	case "", fullPolicyBlock:
+	case fullPolicyDrop:
+		d.drop = true
+	default:
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported Device.AsyncOrdering.FullPolicy %s", info.FullPolicy), nil)
+	}
+	if info.Workers <= 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid Device.AsyncOrdering.Workers %d", info.Workers), nil)
+	}
+
+	d.queues = make([]chan *sdkModels.AsyncValues, info.Workers)
+	for i := range d.queues {
+		d.queues[i] = make(chan *sdkModels.AsyncValues, info.QueueSize)
+	}
+	return d, nil
+}
+
+// start runs a worker processing each queue until the queues are closed by stop.
+func (d *orderedDispatcher) start(wg *sync.WaitGroup, process func(acv *sdkModels.AsyncValues)) {
+	for _, queue := range d.queues {
+		wg.Add(1)
+		go func(queue chan *sdkModels.AsyncValues) {
+			defer wg.Done()
+			for acv := range queue {
+				process(acv)
+			}
+		}(queue)
+	}
+}
+
+// stop closes the queues, the workers exit once they processed the AsyncValues queued.
+func (d *orderedDispatcher) stop() {
+	for _, queue := range d.queues {
+		close(queue)
+	}
+}
+
+// dispatch queues the AsyncValues to the worker of its key. When the queue is full, it
+// waits for room until ctx is done, or drops the AsyncValues with the Drop policy. It
+// returns false if the AsyncValues is not queued.
+func (d *orderedDispatcher) dispatch(ctx context.Context, acv *sdkModels.AsyncValues) bool {
+	queue := d.queues[d.worker(acv)]
+	if d.drop {
+		select {
+		case queue <- acv:
+			return true
+		default:
+			droppedAsyncValues.Add(1)
+			return false
+		}
+	}
+	select {
+	case queue <- acv:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (d *orderedDispatcher) worker(acv *sdkModels.AsyncValues) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(acv.DeviceName))
+	if d.bySource {
+		sourceName := acv.SourceName
+		// the source of a single reading defaults to its resource, see sendAsyncValues
+		if sourceName == "" && len(acv.CommandValues) == 1 {
+			sourceName = acv.CommandValues[0].DeviceResourceName
+		}
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(sourceName))
+	}
+	return int(h.Sum32() % uint32(len(d.queues)))
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestOrderedDispatcher(t *testing.T) {
+	d, err := newOrderedDispatcher(config.AsyncOrderingInfo{OrderBy: orderByDevice, Workers: 4, QueueSize: 2})
+	require.NoError(t, err)
+
+	var mutex sync.Mutex
+	received := make(map[string][]string)
+	var workers sync.WaitGroup
+	d.start(&workers, func(acv *sdkModels.AsyncValues) {
+		// slow down the first device so that the others overtake it
+		if acv.DeviceName == "device-0" {
+			time.Sleep(time.Millisecond)
+		}
+		mutex.Lock()
+		received[acv.DeviceName] = append(received[acv.DeviceName], acv.SourceName)
+		mutex.Unlock()
+	})
+
+	expected := make(map[string][]string)
+	for i := 0; i < 20; i++ {
+		for dev := 0; dev < 3; dev++ {
+			deviceName := fmt.Sprintf("device-%d", dev)
+			sourceName := fmt.Sprintf("source-%d", i)
+			expected[deviceName] = append(expected[deviceName], sourceName)
+			assert.True(t, d.dispatch(context.Background(), &sdkModels.AsyncValues{DeviceName: deviceName, SourceName: sourceName}))
+		}
+	}
+	d.stop()
+	workers.Wait()
+
+	assert.Equal(t, expected, received)
+}
+
+func TestOrderedDispatcher_Drop(t *testing.T) {
+	d, err := newOrderedDispatcher(config.AsyncOrderingInfo{OrderBy: orderByDeviceSource, Workers: 1, QueueSize: 1, FullPolicy: fullPolicyDrop})
+	require.NoError(t, err)
+	dropped := droppedAsyncValues.Value()
+
+	// no worker is started, so the queue is full after the first AsyncValues
+	assert.True(t, d.dispatch(context.Background(), &sdkModels.AsyncValues{DeviceName: "test-device"}))
+	assert.False(t, d.dispatch(context.Background(), &sdkModels.AsyncValues{DeviceName: "test-device"}))
+	assert.Equal(t, int64(1), droppedAsyncValues.Value()-dropped)
+}
+
+func TestOrderedDispatcher_Block(t *testing.T) {
+	d, err := newOrderedDispatcher(config.AsyncOrderingInfo{OrderBy: orderByDevice, Workers: 1, QueueSize: 1})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.True(t, d.dispatch(ctx, &sdkModels.AsyncValues{DeviceName: "test-device"}))
+	// blocks until ctx is done since no worker drains the queue
+	assert.False(t, d.dispatch(ctx, &sdkModels.AsyncValues{DeviceName: "test-device"}))
+	assert.Error(t, ctx.Err())
+}
+
+func TestNewOrderedDispatcher_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		info config.AsyncOrderingInfo
+	}{
+		{"invalid OrderBy", config.AsyncOrderingInfo{OrderBy: "Profile", Workers: 1}},
+		{"invalid FullPolicy", config.AsyncOrderingInfo{OrderBy: orderByDevice, Workers: 1, FullPolicy: "Sample"}},
+		{"no worker", config.AsyncOrderingInfo{OrderBy: orderByDevice}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newOrderedDispatcher(tt.info)
+			assert.Error(t, err)
+		})
+	}
+}
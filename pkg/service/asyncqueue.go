@@ -0,0 +1,127 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// Device.AsyncOverflow.Policy values
+const (
+	overflowBlock      = "Block"
+	overflowDropNewest = "DropNewest"
+	overflowDropOldest = "DropOldest"
+	overflowSample     = "Sample"
+)
+
+var (
+	droppedOverflowValues  = telemetry.NewMetric("AsyncOverflowDropped")
+	rejectedOverflowValues = telemetry.NewMetric("AsyncTryPushRejected")
+)
+
+// errAsyncOverflow is the outcome of the AsyncValues dropped by the overflow policy.
+var errAsyncOverflow = errors.New("async readings channel full")
+
+// asyncQueue is the queue of the AsyncValues waiting to be processed, which applies
+// Device.AsyncOverflow.Policy when it is full. With the Block policy it is the channel the
+// ProtocolDriver pushes to, otherwise that channel is drained into the queue by intake so
+// that the driver never blocks.
+type asyncQueue struct {
+	policy     string
+	sampleRate uint64
+	overflows  uint64
+	ch         chan *sdkModels.AsyncValues
+}
+
+func newAsyncQueue(info config.AsyncOverflowInfo, asyncCh chan *sdkModels.AsyncValues) (*asyncQueue, edgexErrors.EdgeX) {
+	q := &asyncQueue{policy: info.Policy, ch: asyncCh}
+	switch info.Policy {
+	case "":
+		q.policy = overflowBlock
+	case overflowBlock, overflowDropNewest, overflowDropOldest:
+	case overflowSample:
+		if info.SampleRate <= 0 {
+			return nil, edgexErrors.NewCommonEdgeX(edgexErrors.KindContractInvalid, fmt.Sprintf("invalid Device.AsyncOverflow.SampleRate %d", info.SampleRate), nil)
+		}
+		q.sampleRate = uint64(info.SampleRate)
+	default:
+		return nil, edgexErrors.NewCommonEdgeX(edgexErrors.KindContractInvalid, fmt.Sprintf("unsupported Device.AsyncOverflow.Policy %s", info.Policy), nil)
+	}
+	if q.policy != overflowBlock {
+		size := cap(asyncCh)
+		if size < 1 {
+			size = 1
+		}
+		q.ch = make(chan *sdkModels.AsyncValues, size)
+	}
+	return q, nil
+}
+
+// intake moves the AsyncValues pushed by the ProtocolDriver into the queue until ctx is done.
+func (q *asyncQueue) intake(ctx context.Context, asyncCh chan *sdkModels.AsyncValues) {
+	if q.ch == asyncCh {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case acv := <-asyncCh:
+			if !q.tryPush(acv) {
+				reportAsyncOutcome(acv, errAsyncOverflow)
+			}
+		}
+	}
+}
+
+// tryPush queues the AsyncValues without blocking and returns whether it is accepted. When
+// the queue is full, DropOldest evicts the oldest AsyncValues and Sample does the same for
+// one out of SampleRate AsyncValues, while Block and DropNewest reject it.
+func (q *asyncQueue) tryPush(acv *sdkModels.AsyncValues) bool {
+	select {
+	case q.ch <- acv:
+		return true
+	default:
+	}
+
+	switch q.policy {
+	case overflowBlock:
+		rejectedOverflowValues.Add(1)
+		return false
+	case overflowSample:
+		if atomic.AddUint64(&q.overflows, 1)%q.sampleRate != 0 {
+			droppedOverflowValues.Add(1)
+			return false
+		}
+	case overflowDropNewest:
+		droppedOverflowValues.Add(1)
+		return false
+	}
+
+	for {
+		select {
+		case q.ch <- acv:
+			return true
+		default:
+		}
+		select {
+		case evicted := <-q.ch:
+			droppedOverflowValues.Add(1)
+			reportAsyncOutcome(evicted, errAsyncOverflow)
+		default:
+		}
+	}
+}
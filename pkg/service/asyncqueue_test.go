@@ -0,0 +1,165 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func queuedSources(q *asyncQueue) []string {
+	var sources []string
+	for len(q.ch) > 0 {
+		sources = append(sources, (<-q.ch).SourceName)
+	}
+	return sources
+}
+
+func TestAsyncQueue_TryPush(t *testing.T) {
+	tests := []struct {
+		name             string
+		info             config.AsyncOverflowInfo
+		expectedAccepted []bool
+		expectedQueued   []string
+		expectedOverflow []string
+	}{
+		{"Block", config.AsyncOverflowInfo{}, []bool{true, true, false, false, false}, []string{"0", "1"}, nil},
+		{"DropNewest", config.AsyncOverflowInfo{Policy: overflowDropNewest}, []bool{true, true, false, false, false}, []string{"0", "1"}, nil},
+		{"DropOldest", config.AsyncOverflowInfo{Policy: overflowDropOldest}, []bool{true, true, true, true, true}, []string{"3", "4"}, []string{"0", "1", "2"}},
+		{"Sample", config.AsyncOverflowInfo{Policy: overflowSample, SampleRate: 2}, []bool{true, true, false, true, false}, []string{"1", "3"}, []string{"0"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := newAsyncQueue(tt.info, make(chan *sdkModels.AsyncValues, 2))
+			require.NoError(t, err)
+
+			var overflow []string
+			var accepted []bool
+			for i := 0; i < 5; i++ {
+				sourceName := fmt.Sprint(i)
+				acv := &sdkModels.AsyncValues{DeviceName: "test-device", SourceName: sourceName, OnPublished: func(err error) {
+					assert.Equal(t, errAsyncOverflow, err)
+					overflow = append(overflow, sourceName)
+				}}
+				accepted = append(accepted, q.tryPush(acv))
+			}
+
+			assert.Equal(t, tt.expectedAccepted, accepted)
+			assert.Equal(t, tt.expectedQueued, queuedSources(q))
+			assert.Equal(t, tt.expectedOverflow, overflow)
+		})
+	}
+}
+
+func TestAsyncQueue_Intake(t *testing.T) {
+	asyncCh := make(chan *sdkModels.AsyncValues, 1)
+	q, err := newAsyncQueue(config.AsyncOverflowInfo{Policy: overflowDropNewest}, asyncCh)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.intake(ctx, asyncCh)
+
+	// the driver never blocks although nothing consumes the queue
+	dropped := make(chan string, 3)
+	for i := 0; i < 3; i++ {
+		sourceName := fmt.Sprint(i)
+		select {
+		case asyncCh <- &sdkModels.AsyncValues{SourceName: sourceName, OnPublished: func(error) { dropped <- sourceName }}:
+		case <-time.After(time.Second):
+			require.Fail(t, "driver blocked")
+		}
+	}
+	for _, expected := range []string{"1", "2"} {
+		select {
+		case sourceName := <-dropped:
+			assert.Equal(t, expected, sourceName)
+		case <-time.After(time.Second):
+			require.Fail(t, "AsyncValues not dropped")
+		}
+	}
+	assert.Equal(t, []string{"0"}, queuedSources(q))
+}
+
+func TestProcessAsyncResults_Overflow(t *testing.T) {
+	asyncCh := make(chan *sdkModels.AsyncValues, 1)
+	q, err := newAsyncQueue(config.AsyncOverflowInfo{Policy: overflowDropNewest}, asyncCh)
+	require.NoError(t, err)
+	s := &DeviceService{
+		LoggingClient: logger.NewMockClient(),
+		config:        &config.ConfigurationStruct{Device: config.DeviceInfo{AsyncBufferSize: 1}},
+		asyncQueue:    q,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	go q.intake(ctx, asyncCh)
+	go s.processAsyncResults(ctx, wg, nil)
+
+	// the AsyncValues without CommandValues are not published, their outcome is reported
+	// right away from the worker
+	type outcome struct {
+		sourceName string
+		err        error
+	}
+	outcomes := make(chan outcome, 3)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	push := func(sourceName string) {
+		asyncCh <- &sdkModels.AsyncValues{DeviceName: "test-device", SourceName: sourceName, OnPublished: func(err error) {
+			if sourceName == "0" {
+				close(started)
+				<-release
+			}
+			outcomes <- outcome{sourceName, err}
+		}}
+	}
+	receive := func() outcome {
+		select {
+		case o := <-outcomes:
+			return o
+		case <-time.After(time.Second):
+			require.Fail(t, "AsyncValues outcome not reported")
+		}
+		return outcome{}
+	}
+
+	// the single worker is busy, so the next AsyncValues waits in the queue and the one
+	// after is dropped by the overflow policy
+	push("0")
+	<-started
+	push("1")
+	push("2")
+	o := receive()
+	assert.Equal(t, "2", o.sourceName)
+	assert.Equal(t, errAsyncOverflow, o.err)
+
+	close(release)
+	assert.Equal(t, "0", receive().sourceName)
+	o = receive()
+	assert.Equal(t, "1", o.sourceName)
+	assert.NotEqual(t, errAsyncOverflow, o.err)
+}
+
+func TestNewAsyncQueue_Invalid(t *testing.T) {
+	_, err := newAsyncQueue(config.AsyncOverflowInfo{Policy: "Spill"}, make(chan *sdkModels.AsyncValues))
+	assert.Error(t, err)
+	_, err = newAsyncQueue(config.AsyncOverflowInfo{Policy: overflowSample}, make(chan *sdkModels.AsyncValues))
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2022 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type nopEventSink struct{}
+
+func (nopEventSink) Name() string {
+	return "nop"
+}
+
+func (nopEventSink) Send(dtos.Event, string) error {
+	return nil
+}
+
+func TestAddEventSink_Concurrent(t *testing.T) {
+	configuration := &config.ConfigurationStruct{}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	s := &DeviceService{
+		LoggingClient: logger.NewMockClient(),
+		config:        configuration,
+		ctx:           ctx,
+		wg:            wg,
+		dic:           dic,
+	}
+	common.StartEventSinks(ctx, wg, sdkModels.EventSinkFilter{}, nil, dic)
+	s.sinksStarted = true
+
+	// the sinks added while others are added or the publish filter is set are all kept
+	const added = 10
+	var adding sync.WaitGroup
+	for i := 0; i < added; i++ {
+		adding.Add(2)
+		go func() {
+			defer adding.Done()
+			s.AddEventSink(nopEventSink{}, sdkModels.EventSinkFilter{})
+		}()
+		go func() {
+			defer adding.Done()
+			s.SetPublishFilter(sdkModels.EventSinkFilter{DeviceNames: []string{"test-device"}})
+		}()
+	}
+	adding.Wait()
+
+	assert.Len(t, s.eventSinks, added)
+	assert.Len(t, container.EventSinksFrom(dic.Get), added+1)
+}
@@ -9,13 +9,20 @@ package service
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/gorilla/mux"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/application"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/controller/messaging"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/eventstore"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/objectstore"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/provision"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 	"github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 )
 
@@ -43,8 +50,57 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		return false
 	}
 
+	err = application.ValidateCommandTimeout(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to validate the command timeout: %v", err)
+		return false
+	}
+
+	err = application.ValidateVerifyDelay(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to validate the verification delay: %v", err)
+		return false
+	}
+
+	err = application.ValidateRetry(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to validate the retry policy: %v", err)
+		return false
+	}
+
+	err = sdkCommon.ValidatePublishTopicTemplate(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to parse the event publish topic template: %v", err)
+		return false
+	}
+
+	err = sdkCommon.ValidateEventPublish(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to validate the event publishing configuration: %v", err)
+		return false
+	}
+
+	// the stores and sinks of the events are registered before the async readings are
+	// processed and the ProtocolDriver is initialized, which may already send events
+	if !ds.startEventStores(ctx, wg, dic) {
+		return false
+	}
+
 	if ds.AsyncReadings() {
+		if ds.config.Device.AsyncOrdering.OrderBy != "" {
+			ds.asyncDispatcher, err = newOrderedDispatcher(ds.config.Device.AsyncOrdering)
+			if err != nil {
+				ds.LoggingClient.Errorf("Failed to init the ordered processing of async readings: %v", err)
+				return false
+			}
+		}
 		ds.asyncCh = make(chan *models.AsyncValues, ds.config.Device.AsyncBufferSize)
+		ds.asyncQueue, err = newAsyncQueue(ds.config.Device.AsyncOverflow, ds.asyncCh)
+		if err != nil {
+			ds.LoggingClient.Errorf("Failed to init the async readings channel: %v", err)
+			return false
+		}
+		go ds.asyncQueue.intake(ctx, ds.asyncCh)
 		go ds.processAsyncResults(ctx, wg, dic)
 	}
 	if ds.DeviceDiscovery() {
@@ -77,7 +133,81 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		return false
 	}
 
+	err = sdkCommon.StartEventBatcher(ctx, wg, dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to start the batching of events: %v", err)
+		return false
+	}
+
+	application.StartHealthProbes(dic)
 	ds.manager.StartAutoEvents()
 
+	err = messaging.SubscribeCommands(ctx, wg, dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to subscribe to MessageBus device commands: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// startEventStores registers the stores and the sinks of the events, the default ones unless
+// set by the ProtocolDriver, and starts the store and forward of the events if enabled.
+func (ds *DeviceService) startEventStores(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) bool {
+	ds.sinksMutex.Lock()
+	defer ds.sinksMutex.Unlock()
+
+	err := transformer.ValidateBinaryStream(dic)
+	if err != nil {
+		ds.LoggingClient.Errorf("Failed to validate the binary stream configuration: %v", err)
+		return false
+	}
+	if ds.config.Device.BinaryStream.Mode != sdkCommon.BinaryStreamChunked {
+		if ds.objectStore == nil {
+			store, e := objectstore.NewFileStore(ds.config.Device.BinaryStream.Path)
+			if e != nil {
+				ds.LoggingClient.Errorf("Failed to open the object store: %v", e)
+				return false
+			}
+			if ds.config.Device.BinaryStream.Retention != "" {
+				// the retention is validated along with the binary stream configuration
+				retention, _ := time.ParseDuration(ds.config.Device.BinaryStream.Retention)
+				store.StartPruning(ctx, wg, retention, ds.LoggingClient)
+			}
+			ds.objectStore = store
+		}
+		ds.registerObjectStore()
+	}
+
+	sinks := ds.eventSinks
+	if ds.config.Device.LocalEventSink.Enabled {
+		store, e := eventstore.NewFileStore(ds.config.Device.LocalEventSink.Path)
+		if e != nil {
+			ds.LoggingClient.Errorf("Failed to open the local event sink: %v", e)
+			return false
+		}
+		sink := eventstore.NewRingSink(store, ds.config.Device.LocalEventSink.MaxEvents)
+		sinks = append(sinks, sdkCommon.NewFilteredEventSink(sink, models.EventSinkFilter{}))
+	}
+	sdkCommon.StartEventSinks(ctx, wg, ds.publishFilter, sinks, dic)
+
+	if ds.config.Device.StoreAndForward.Enabled {
+		if ds.eventStore == nil {
+			var e error
+			ds.eventStore, e = eventstore.NewFileStore(ds.config.Device.StoreAndForward.Path)
+			if e != nil {
+				ds.LoggingClient.Errorf("Failed to open the event store: %v", e)
+				return false
+			}
+		}
+		ds.registerEventStore()
+		err = sdkCommon.StartEventForwarder(ctx, wg, dic)
+		if err != nil {
+			ds.LoggingClient.Errorf("Failed to start the store and forward of events: %v", err)
+			return false
+		}
+	}
+	// the stores and sinks set by the ProtocolDriver from now on are registered right away
+	ds.sinksStarted = true
 	return true
 }
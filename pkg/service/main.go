@@ -56,6 +56,12 @@ func Main(serviceName string, serviceVersion string, proto interface{}, ctx cont
 		container.ProtocolDriverName: func(get di.Get) interface{} {
 			return ds.driver
 		},
+		container.ContextualProtocolDriverName: func(get di.Get) interface{} {
+			return ds.contextDriver
+		},
+		container.HealthCheckerName: func(get di.Get) interface{} {
+			return ds.healthChecker
+		},
 		container.ProtocolDiscoveryName: func(get di.Get) interface{} {
 			return ds.discovery
 		},
@@ -14,6 +14,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strconv"
@@ -67,12 +68,22 @@ type DeviceService struct {
 	config          *config.ConfigurationStruct
 	deviceService   *models.DeviceService
 	driver          sdkModels.ProtocolDriver
+	contextDriver   sdkModels.ContextualProtocolDriver
+	healthChecker   sdkModels.HealthChecker
 	discovery       sdkModels.ProtocolDiscovery
 	validator       sdkModels.DeviceValidator
+	eventStore      sdkModels.EventStore
+	eventSinks      []sdkModels.EventSink
+	publishFilter   sdkModels.EventSinkFilter
+	objectStore     sdkModels.ObjectStore
 	manager         sdkModels.AutoEventManager
 	asyncCh         chan *sdkModels.AsyncValues
+	asyncQueue      *asyncQueue
+	asyncDispatcher *orderedDispatcher
 	deviceCh        chan []sdkModels.DiscoveredDevice
 	initialized     bool
+	sinksStarted    bool
+	sinksMutex      sync.Mutex
 	dic             *di.Container
 	flags           flags.Common
 	configProcessor *bootstrapConfig.Processor
@@ -100,6 +111,18 @@ func (s *DeviceService) Initialize(serviceName, serviceVersion string, proto int
 		os.Exit(1)
 	}
 
+	if contextDriver, ok := proto.(sdkModels.ContextualProtocolDriver); ok {
+		s.contextDriver = contextDriver
+	} else {
+		s.contextDriver = nil
+	}
+
+	if healthChecker, ok := proto.(sdkModels.HealthChecker); ok {
+		s.healthChecker = healthChecker
+	} else {
+		s.healthChecker = nil
+	}
+
 	if discovery, ok := proto.(sdkModels.ProtocolDiscovery); ok {
 		s.discovery = discovery
 	} else {
@@ -154,6 +177,89 @@ func (s *DeviceService) AddRoute(route string, handler func(http.ResponseWriter,
 	return s.controller.AddRoute(route, handler, methods...)
 }
 
+// SetEventStore replaces the default file-backed store of the events failed to be published,
+// which is used when Device.StoreAndForward is enabled. The events already stored in the
+// default store are left to it.
+func (s *DeviceService) SetEventStore(store sdkModels.EventStore) {
+	s.sinksMutex.Lock()
+	defer s.sinksMutex.Unlock()
+	s.eventStore = store
+	if s.sinksStarted && s.config.Device.StoreAndForward.Enabled {
+		s.registerEventStore()
+	}
+}
+
+// SetObjectStore replaces the default local filesystem store of the binary values streamed
+// by the ProtocolDriver, which is used when Device.BinaryStream.Mode is ObjectStore.
+func (s *DeviceService) SetObjectStore(store sdkModels.ObjectStore) {
+	s.sinksMutex.Lock()
+	defer s.sinksMutex.Unlock()
+	s.objectStore = store
+	if s.sinksStarted && s.config.Device.BinaryStream.Mode != sdkCommon.BinaryStreamChunked {
+		s.registerObjectStore()
+	}
+}
+
+// registerEventStore registers the event store in the DIC. The sinksMutex must be held.
+func (s *DeviceService) registerEventStore() {
+	store := s.eventStore
+	s.dic.Update(di.ServiceConstructorMap{
+		container.EventStoreName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+}
+
+// registerObjectStore registers the object store in the DIC. The sinksMutex must be held.
+func (s *DeviceService) registerObjectStore() {
+	store := s.objectStore
+	s.dic.Update(di.ServiceConstructorMap{
+		container.ObjectStoreName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+}
+
+// TryPushAsyncValues queues the AsyncValues without blocking, applying Device.AsyncOverflow.Policy
+// when the async readings channel is full, and returns whether the AsyncValues is accepted.
+// It returns false when EnableAsyncReadings is not set.
+func (s *DeviceService) TryPushAsyncValues(acv *sdkModels.AsyncValues) bool {
+	if s.asyncQueue == nil {
+		return false
+	}
+	return s.asyncQueue.tryPush(acv)
+}
+
+// AddEventSink registers a sink receiving the events passing the filter, in addition to
+// core-data or the MessageBus, from the events sent after it is registered.
+func (s *DeviceService) AddEventSink(sink sdkModels.EventSink, filter sdkModels.EventSinkFilter) {
+	filtered := sdkCommon.NewFilteredEventSink(sink, filter)
+	s.sinksMutex.Lock()
+	defer s.sinksMutex.Unlock()
+	s.eventSinks = append(s.eventSinks, filtered)
+	if s.sinksStarted {
+		sdkCommon.AddEventSink(s.ctx, s.wg, filtered, s.dic)
+	}
+}
+
+// SetPublishFilter restricts the events published to core-data or the MessageBus by the
+// built-in EventSink to the ones passing the filter, the additional sinks still receiving
+// all of their events.
+func (s *DeviceService) SetPublishFilter(filter sdkModels.EventSinkFilter) {
+	s.sinksMutex.Lock()
+	defer s.sinksMutex.Unlock()
+	s.publishFilter = filter
+	if s.sinksStarted {
+		sdkCommon.SetPublishFilter(s.ctx, filter, s.dic)
+	}
+}
+
+// RegisterEventCompressor makes the named compression (e.g. lz4) available to
+// Device.EventBatching.Compression in addition to the builtin gzip and zstd.
+func (s *DeviceService) RegisterEventCompressor(name string, compressor func(w io.Writer) (io.WriteCloser, error)) {
+	sdkCommon.RegisterCompressor(name, compressor)
+}
+
 // Stop shuts down the Service
 func (s *DeviceService) Stop(force bool) {
 	if s.initialized {